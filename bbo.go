@@ -0,0 +1,100 @@
+package orderbook
+
+import (
+	"time"
+)
+
+// BBO best bid and offer, zero price and size when side is empty
+type BBO struct {
+	BidPrice int64
+	BidSize  int64
+	AskPrice int64
+	AskSize  int64
+	UpdateID int64
+	Time     time.Time
+}
+
+// equal reports whether best prices and sizes are the same
+func (b BBO) equal(other BBO) bool {
+	return b.BidPrice == other.BidPrice && b.BidSize == other.BidSize &&
+		b.AskPrice == other.AskPrice && b.AskSize == other.AskSize
+}
+
+// WithBBOListener calls fn whenever best bid or ask price or size changes. By default fn is called for
+// every level of an event that changes top of book, see WithBBOCoalesce. fn is called while book is
+// updated and must not block, use Subscribe for slow consumers.
+func WithBBOListener(fn func(bbo BBO)) Option {
+	return func(ob *OrderBook) {
+		ob.bboListeners = append(ob.bboListeners, fn)
+	}
+}
+
+// WithBBOCoalesce calls BBO listeners at most once per update with top of book after the update
+func WithBBOCoalesce() Option {
+	return func(ob *OrderBook) {
+		ob.bboCoalesce = true
+	}
+}
+
+// BBO returns current best bid and offer
+func (ob *OrderBook) BBO() BBO {
+	bbo := BBO{
+		UpdateID: ob.LastUpdateID,
+		Time:     ob.UpdatedAt,
+	}
+
+	if bid, err := ob.Bids.Front(); err == nil {
+		bbo.BidPrice = bid.Price
+		bbo.BidSize = bid.Size
+	}
+
+	if ask, err := ob.Asks.Front(); err == nil {
+		bbo.AskPrice = ask.Price
+		bbo.AskSize = ask.Size
+	}
+
+	return bbo
+}
+
+// applyEvent applies validated event levels, BBO listeners are checked after every level unless coalesced
+func (ob *OrderBook) applyEvent(event *DepthEvent, now time.Time) error {
+	if len(ob.bboListeners) == 0 || ob.bboCoalesce {
+		return applyLevels(ob.Asks, ob.Bids, event.Asks, event.Bids, event.FinalUpdateID, now)
+	}
+
+	for _, ask := range event.Asks {
+		if err := setLevel(ob.Asks, ask.level(event.FinalUpdateID, now), ask.Delete); err != nil {
+			return err
+		}
+		ob.checkBBO(event.FinalUpdateID, now)
+	}
+
+	for _, bid := range event.Bids {
+		if err := setLevel(ob.Bids, bid.level(event.FinalUpdateID, now), bid.Delete); err != nil {
+			return err
+		}
+		ob.checkBBO(event.FinalUpdateID, now)
+	}
+
+	return nil
+}
+
+// checkBBO notifies BBO listeners if top of book changed since last notification
+func (ob *OrderBook) checkBBO(updateID int64, at time.Time) {
+	if len(ob.bboListeners) == 0 {
+		return
+	}
+
+	bbo := ob.BBO()
+	if bbo.equal(ob.lastBBO) {
+		return
+	}
+
+	bbo.UpdateID = updateID
+	bbo.Time = at
+	ob.lastBBO = bbo
+
+	for _, fn := range ob.bboListeners {
+		fn(bbo)
+	}
+}
@@ -0,0 +1,77 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+// bboEvent moves best ask twice and changes level below best bid
+func bboEvent() *DepthEvent {
+	return &DepthEvent{
+		FinalUpdateID: 101,
+		Asks: []*Ask{
+			{Price: 4800100000000, Delete: true},
+			{Price: 4800200000000, Delete: true},
+		},
+		Bids: []*Bid{{Price: 4799800000000, Quantity: 5}},
+	}
+}
+
+// TestBBOListener checks listener is called for every top of book change
+func TestBBOListener(t *testing.T) {
+	var bbos []BBO
+
+	ob := New("BTCUSDT", 10, WithBBOListener(func(bbo BBO) {
+		bbos = append(bbos, bbo)
+	}))
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	if len(bbos) != 1 || bbos[0].BidPrice != 4799900000000 || bbos[0].AskPrice != 4800100000000 || bbos[0].UpdateID != 100 {
+		t.Errorf("Invalid snapshot BBO! Got: %+v", bbos)
+	}
+
+	ob.ProcessEvent(bboEvent())
+
+	if len(bbos) != 3 {
+		t.Errorf("Invalid BBO count! Expected: %d, got: %d", 3, len(bbos))
+		return
+	}
+
+	if bbos[1].AskPrice != 4800200000000 || bbos[2].AskPrice != 4800300000000 || bbos[2].UpdateID != 101 {
+		t.Errorf("Invalid BBO asks! Got: %d, %d", bbos[1].AskPrice, bbos[2].AskPrice)
+	}
+
+	// Update below top of book
+	ob.ProcessEvent(&DepthEvent{
+		FinalUpdateID: 102,
+		Bids:          []*Bid{{Price: 4799700000000, Quantity: 5}},
+	})
+
+	if len(bbos) != 3 {
+		t.Errorf("Invalid BBO count! Expected: %d, got: %d", 3, len(bbos))
+	}
+
+	if bbo := ob.BBO(); !bbo.equal(bbos[2]) || bbo.UpdateID != 102 {
+		t.Errorf("Invalid BBO! Expected: %+v, got: %+v", bbos[2], bbo)
+	}
+}
+
+// TestBBOCoalesce checks listener is called once per event
+func TestBBOCoalesce(t *testing.T) {
+	var bbos []BBO
+
+	ob := New("BTCUSDT", 10, WithBBOCoalesce(), WithBBOListener(func(bbo BBO) {
+		bbos = append(bbos, bbo)
+	}))
+	ob.ProcessSnapshot(testSnapshot(), nil)
+	ob.ProcessEvent(bboEvent())
+
+	if len(bbos) != 2 || bbos[1].AskPrice != 4800300000000 || bbos[1].BidPrice != 4799900000000 {
+		t.Errorf("Invalid coalesced BBOs! Got: %+v", bbos)
+	}
+
+	ob.Clear()
+
+	if len(bbos) != 3 || bbos[2].AskPrice != 0 || bbos[2].BidPrice != 0 {
+		t.Errorf("Invalid cleared BBO! Got: %+v", bbos)
+	}
+}
@@ -0,0 +1,95 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// binanceDepthEvent spot and USDⓈ-M futures depth update, futures add T and pu
+type binanceDepthEvent struct {
+	Event         string      `json:"e"`
+	EventTime     int64       `json:"E"`
+	Symbol        string      `json:"s"`
+	FirstUpdateID int64       `json:"U"`
+	FinalUpdateID int64       `json:"u"`
+	PrevUpdateID  int64       `json:"pu"`
+	Bids          [][2]string `json:"b"`
+	Asks          [][2]string `json:"a"`
+}
+
+// binanceDepthSnapshot spot /api/v3/depth and futures /fapi/v1/depth response
+type binanceDepthSnapshot struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+// binanceStream combined stream wrapper
+type binanceStream struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// DecodeBinanceDepthEvent decodes Binance spot @depth / @depth@100ms or USDⓈ-M futures depth update,
+// raw or wrapped in combined stream payload. Futures pu is mapped to PrevUpdateID.
+func DecodeBinanceDepthEvent(d LevelDecoder, data []byte) (*DepthEvent, error) {
+	var stream binanceStream
+	if err := json.Unmarshal(data, &stream); err == nil && stream.Stream != "" && len(stream.Data) > 0 {
+		data = stream.Data
+	}
+
+	var msg binanceDepthEvent
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("binance depth event: %w", err)
+	}
+
+	if msg.Event != "depthUpdate" {
+		return nil, fmt.Errorf("binance depth event: unexpected event type %q", msg.Event)
+	}
+
+	asks, err := d.Asks(msg.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("binance depth event(%s) %d: %w", msg.Symbol, msg.FinalUpdateID, err)
+	}
+
+	bids, err := d.Bids(msg.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("binance depth event(%s) %d: %w", msg.Symbol, msg.FinalUpdateID, err)
+	}
+
+	return &DepthEvent{
+		Symbol:        msg.Symbol,
+		FirstUpdateID: msg.FirstUpdateID,
+		FinalUpdateID: msg.FinalUpdateID,
+		PrevUpdateID:  msg.PrevUpdateID,
+		Asks:          asks,
+		Bids:          bids,
+		Timestamp:     time.UnixMilli(msg.EventTime),
+	}, nil
+}
+
+// DecodeBinanceDepthSnapshot decodes Binance spot /api/v3/depth or futures /fapi/v1/depth response
+func DecodeBinanceDepthSnapshot(d LevelDecoder, symbol string, data []byte) (*DepthSnapshot, error) {
+	var msg binanceDepthSnapshot
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("binance depth snapshot: %w", err)
+	}
+
+	asks, err := d.Asks(msg.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("binance depth snapshot(%s) %d: %w", symbol, msg.LastUpdateID, err)
+	}
+
+	bids, err := d.Bids(msg.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("binance depth snapshot(%s) %d: %w", symbol, msg.LastUpdateID, err)
+	}
+
+	return &DepthSnapshot{
+		Symbol:       symbol,
+		LastUpdateID: msg.LastUpdateID,
+		Asks:         asks,
+		Bids:         bids,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBinanceSpot applies hand-written spot snapshot and diffs
+func TestBinanceSpot(t *testing.T) {
+	snapshot, err := DecodeBinanceDepthSnapshot(DefaultLevelDecoder, "BTCUSDT", readFixture(t, "binance/spot_depth_snapshot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob := New("BTCUSDT", 100)
+	if err := ob.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range readFixtureLines(t, "binance/spot_depth_updates.jsonl") {
+		event, err := DecodeBinanceDepthEvent(DefaultLevelDecoder, line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ob.ProcessEvent(event); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if ob.LastUpdateID != 1027031 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 1027031, ob.LastUpdateID)
+	}
+
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{4800250000000, 1100000}, {4800300000000, 400000}, {4800500000000, 3000000}})
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{4800050000000, 1000000}, {4800000000000, 800000}, {4799910000000, 2000000}})
+}
+
+// TestBinanceFutures checks pu continuity
+func TestBinanceFutures(t *testing.T) {
+	snapshot, err := DecodeBinanceDepthSnapshot(DefaultLevelDecoder, "BTCUSDT", readFixture(t, "binance/futures_depth_snapshot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob := New("BTCUSDT", 100)
+	ob.ProcessSnapshot(snapshot, nil)
+
+	var errs []error
+	for _, line := range readFixtureLines(t, "binance/futures_depth_updates.jsonl") {
+		event, err := DecodeBinanceDepthEvent(DefaultLevelDecoder, line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		errs = append(errs, ob.ProcessEvent(event))
+	}
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Errorf("Expected continuous events, got: %v, %v", errs[0], errs[1])
+	}
+
+	var gap *ErrSequenceGap
+	if !errors.As(errs[2], &gap) || !gap.Previous || gap.Expected != 1027035 || ob.Loaded {
+		t.Errorf("Expected previous update ID gap, got: %v", errs[2])
+	}
+}
+
+// TestBinanceDecodeErrors checks malformed payloads
+func TestBinanceDecodeErrors(t *testing.T) {
+	payloads := []string{
+		`{"e":"trade","s":"BTCUSDT"}`,
+		`{"e":"depthUpdate","s":"BTCUSDT","U":1,"u":2,"b":[["abc","1"]],"a":[]}`,
+		`{"e":"depthUpdate","s":"BTCUSDT","U":1,"u":2,"b":[],"a":[["1.123456789","1"]]}`,
+		`not json`,
+	}
+
+	for _, payload := range payloads {
+		if _, err := DecodeBinanceDepthEvent(DefaultLevelDecoder, []byte(payload)); err == nil {
+			t.Errorf("Expected decode error for %s", payload)
+		}
+	}
+
+	if _, err := DecodeBinanceDepthEvent(DefaultLevelDecoder, []byte(`{"e":"depthUpdate","s":"BTCUSDT","U":1,"u":2,"b":[["1.5","0.5e-3"]],"a":[]}`)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,278 @@
+package orderbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bitfinexEvent subscription event message
+type bitfinexEvent struct {
+	Event     string `json:"event"`
+	Channel   string `json:"channel"`
+	ChannelID int64  `json:"chanId"`
+	Symbol    string `json:"symbol"`
+	Precision string `json:"prec"`
+}
+
+// BitfinexAdapter converts Bitfinex WebSocket v2 precision (P0-P4) book channel messages. Levels are
+// [price, count, amount] tuples, amount sign selects side (positive bids, negative asks) and count 0
+// removes level, count is kept as level order Count. Bitfinex sends no update IDs, adapter assigns
+// synthetic per channel IDs increasing by one per message, message failing to decode still takes its ID so
+// following update reports sequence gap. Channels are registered from subscribed events, messages of
+// unknown channels, raw (R0) and funding books are ignored. First list of levels after subscribing is
+// snapshot, following lists are bulk updates (BULK_UPDATES flag) converted to single event keeping last
+// change per price. Checksum messages are converted to events without levels, books created by NewBook
+// verify them and are marked not loaded on mismatch. One adapter serves one connection, channel IDs are
+// only unique per connection and are registered by Decode without locking.
+type BitfinexAdapter struct {
+	Decoder LevelDecoder
+
+	channels map[int64]string
+	ids      map[int64]int64
+	// snapshots marks channels that received snapshot
+	snapshots map[int64]bool
+}
+
+// NewBitfinexAdapter creates new struct instance of *BitfinexAdapter
+func NewBitfinexAdapter(decoder LevelDecoder) *BitfinexAdapter {
+	return &BitfinexAdapter{
+		Decoder:   decoder,
+		channels:  make(map[int64]string),
+		ids:       make(map[int64]int64),
+		snapshots: make(map[int64]bool),
+	}
+}
+
+// NewBook creates book verifying Bitfinex checksums, pruneThreshold should match subscribed length
+func (a *BitfinexAdapter) NewBook(symbol string, pruneThreshold int, options ...Option) *OrderBook {
+	return a.Decoder.newBook(symbol, pruneThreshold, options, WithChecksum(ChecksumConfig{Scheme: ChecksumBitfinex}))
+}
+
+// Decode converts book snapshot to DepthSnapshot, book update and checksum to DepthEvent. Subscription
+// events register channels and, as other messages (heartbeats, info, other channels), return nil
+// snapshot and event.
+func (a *BitfinexAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return nil, nil, a.event(data)
+	}
+
+	var msg []json.RawMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("bitfinex book: %w", err)
+	}
+
+	if len(msg) < 2 {
+		return nil, nil, fmt.Errorf("bitfinex book: invalid message %s", data)
+	}
+
+	var channelID int64
+	if err := json.Unmarshal(msg[0], &channelID); err != nil {
+		return nil, nil, fmt.Errorf("bitfinex book: %w", err)
+	}
+
+	symbol, ok := a.channels[channelID]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	// Heartbeat or checksum
+	var kind string
+	if json.Unmarshal(msg[1], &kind) == nil {
+		if kind != "cs" {
+			return nil, nil, nil
+		}
+
+		event, err := a.checksum(channelID, symbol, msg)
+		return nil, event, err
+	}
+
+	// Snapshot and bulk update hold list of levels, update single level
+	var levels [][]json.Number
+	list := json.Unmarshal(msg[1], &levels) == nil
+	if list && !a.snapshots[channelID] {
+		snapshot, err := a.snapshot(channelID, symbol, levels)
+		return snapshot, nil, err
+	}
+
+	// Update IDs also count checksum messages, ID is taken before decoding so failed update leaves gap
+	id := a.nextID(channelID)
+	event := &DepthEvent{
+		Symbol:        symbol,
+		FirstUpdateID: id,
+		FinalUpdateID: id,
+	}
+
+	if !list {
+		var level []json.Number
+		if err := json.Unmarshal(msg[1], &level); err != nil {
+			return nil, nil, fmt.Errorf("bitfinex book(%s): %w", symbol, err)
+		}
+		levels = [][]json.Number{level}
+	}
+
+	for _, level := range levels {
+		if err := a.level(level, &event.Asks, &event.Bids); err != nil {
+			return nil, nil, fmt.Errorf("bitfinex book(%s): %w", symbol, err)
+		}
+	}
+	event.Asks = lastAsks(event.Asks)
+	event.Bids = lastBids(event.Bids)
+
+	return nil, event, nil
+}
+
+// event handles subscription events
+func (a *BitfinexAdapter) event(data []byte) error {
+	var msg bitfinexEvent
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("bitfinex event: %w", err)
+	}
+
+	switch msg.Event {
+	case "subscribed":
+		// Raw books carry orders, funding books rate and period
+		if msg.Channel != "book" || msg.Precision == "R0" || !strings.HasPrefix(msg.Symbol, "t") {
+			return nil
+		}
+
+		a.channels[msg.ChannelID] = msg.Symbol
+		delete(a.snapshots, msg.ChannelID)
+	case "unsubscribed":
+		delete(a.channels, msg.ChannelID)
+		delete(a.snapshots, msg.ChannelID)
+	}
+
+	return nil
+}
+
+// nextID returns next synthetic channel update ID
+func (a *BitfinexAdapter) nextID(channelID int64) int64 {
+	a.ids[channelID]++
+	return a.ids[channelID]
+}
+
+// snapshot converts snapshot levels
+func (a *BitfinexAdapter) snapshot(channelID int64, symbol string, levels [][]json.Number) (*DepthSnapshot, error) {
+	snapshot := &DepthSnapshot{
+		Symbol: symbol,
+	}
+
+	for _, level := range levels {
+		if err := a.level(level, &snapshot.Asks, &snapshot.Bids); err != nil {
+			return nil, fmt.Errorf("bitfinex snapshot(%s): %w", symbol, err)
+		}
+	}
+
+	snapshot.LastUpdateID = a.nextID(channelID)
+	a.snapshots[channelID] = true
+
+	return snapshot, nil
+}
+
+// lastAsks keeps last change per price, bulk updates may change price repeatedly
+func lastAsks(asks []*Ask) []*Ask {
+	if len(asks) < 2 {
+		return asks
+	}
+
+	last := make(map[int64]int, len(asks))
+	for i, ask := range asks {
+		last[ask.Price] = i
+	}
+
+	kept := asks[:0]
+	for i, ask := range asks {
+		if last[ask.Price] == i {
+			kept = append(kept, ask)
+		}
+	}
+
+	return kept
+}
+
+// lastBids keeps last change per price, bulk updates may change price repeatedly
+func lastBids(bids []*Bid) []*Bid {
+	if len(bids) < 2 {
+		return bids
+	}
+
+	last := make(map[int64]int, len(bids))
+	for i, bid := range bids {
+		last[bid.Price] = i
+	}
+
+	kept := bids[:0]
+	for i, bid := range bids {
+		if last[bid.Price] == i {
+			kept = append(kept, bid)
+		}
+	}
+
+	return kept
+}
+
+// checksum converts [chanId, "cs", checksum] message to event without levels
+func (a *BitfinexAdapter) checksum(channelID int64, symbol string, msg []json.RawMessage) (*DepthEvent, error) {
+	id := a.nextID(channelID)
+	if len(msg) < 3 {
+		return nil, fmt.Errorf("bitfinex checksum(%s): missing checksum", symbol)
+	}
+
+	var checksum int32
+	if err := json.Unmarshal(msg[2], &checksum); err != nil {
+		return nil, fmt.Errorf("bitfinex checksum(%s): %w", symbol, err)
+	}
+
+	return &DepthEvent{
+		Symbol:        symbol,
+		FirstUpdateID: id,
+		FinalUpdateID: id,
+		Checksum:      uint32(checksum),
+		HasChecksum:   true,
+	}, nil
+}
+
+// level decodes [price, count, amount] level, count 0 removes level (amount 1 bid, -1 ask)
+func (a *BitfinexAdapter) level(level []json.Number, asks *[]*Ask, bids *[]*Bid) error {
+	if len(level) != 3 {
+		return fmt.Errorf("invalid level %v", level)
+	}
+
+	count, err := strconv.ParseInt(level[1].String(), 10, 64)
+	if err != nil || count < 0 {
+		return fmt.Errorf("invalid level count %q", level[1])
+	}
+
+	amount := level[2].String()
+	ask := strings.HasPrefix(amount, "-")
+	if ask {
+		amount = amount[1:]
+	}
+
+	if count == 0 {
+		amount = "0"
+	}
+
+	if ask {
+		l, err := a.Decoder.Ask(level[0].String(), amount)
+		if err != nil {
+			return err
+		}
+		l.Count = count
+		*asks = append(*asks, l)
+		return nil
+	}
+
+	l, err := a.Decoder.Bid(level[0].String(), amount)
+	if err != nil {
+		return err
+	}
+	l.Count = count
+	*bids = append(*bids, l)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBitfinexAdapter applies hand-written P0 book messages
+func TestBitfinexAdapter(t *testing.T) {
+	adapter := NewBitfinexAdapter(LevelDecoder{Instrument: Instrument{PriceExp: -8, SizeExp: -8}})
+
+	ob := adapter.NewBook("tBTCUSD", 25, WithViews())
+	sub := ob.Subscribe(16, BackPressureDropOldest)
+
+	r := replayFixture(t, ob, readFixtureLines(t, "bitfinex/book.jsonl"), adapter.Decode)
+	checkNoErrors(t, r.errs)
+
+	// Trades channel and heartbeat are ignored, checksums are events
+	if r.snapshots != 1 || r.events != 6 || ob.LastUpdateID != 7 {
+		t.Errorf("Invalid messages! Expected: %d/%d up to update ID %d, got: %d/%d up to %d", 1, 6, 7, r.snapshots, r.events, ob.LastUpdateID)
+	}
+
+	if !ob.Loaded {
+		t.Errorf("Expected book to be loaded")
+	}
+
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{1000200000000, 320000000}, {1000300000000, 100000000}, {1000400000000, 75000000}})
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{1000000000000, 250000000}, {999900000000, 25000000}})
+
+	// Order counts are kept on book and view levels
+	var counts []int64
+	ob.View().Bids.Each(func(node *ListNode) bool {
+		counts = append(counts, node.Count)
+		return true
+	})
+	ob.Asks.Each(func(node *ListNode) bool {
+		counts = append(counts, node.Count)
+		return true
+	})
+
+	expected := []int64{3, 1, 4, 2, 1}
+	if len(counts) != len(expected) {
+		t.Fatalf("Invalid level count! Expected: %d, got: %d", len(expected), len(counts))
+	}
+	for i := range expected {
+		if counts[i] != expected[i] {
+			t.Errorf("Invalid order count %d! Expected: %d, got: %d", i, expected[i], counts[i])
+		}
+	}
+
+	// Snapshot, checksum event without changes and first update
+	<-sub.C
+	<-sub.C
+	update := <-sub.C
+	if len(update.Deltas) != 1 || update.Deltas[0].Count != 3 {
+		t.Errorf("Invalid delta! Got: %+v", update.Deltas)
+	}
+
+	// Deleted ask
+	update = <-sub.C
+	if delta := update.Deltas[0]; delta.Side != OrderBookSideAsks || delta.Size != 0 || delta.Count != 0 {
+		t.Errorf("Invalid delta! Got: %+v", delta)
+	}
+}
+
+// TestBitfinexAdapterChecksumMismatch marks book not loaded
+func TestBitfinexAdapterChecksumMismatch(t *testing.T) {
+	adapter := NewBitfinexAdapter(LevelDecoder{Instrument: Instrument{PriceExp: -8, SizeExp: -8}})
+	ob := adapter.NewBook("tBTCUSD", 25)
+
+	lines := readFixtureLines(t, "bitfinex/book.jsonl")
+	checkNoErrors(t, replayFixture(t, ob, lines[:5], adapter.Decode).errs)
+
+	_, event, err := adapter.Decode([]byte(`[17082,"cs",12345]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ob.ProcessEvent(event); err == nil || ob.Loaded {
+		t.Errorf("Expected checksum mismatch")
+	}
+}
+
+// TestBitfinexAdapterInvalid rejects malformed levels and ignores unknown channels
+func TestBitfinexAdapterInvalid(t *testing.T) {
+	adapter := NewBitfinexAdapter(DefaultLevelDecoder)
+	adapter.Decode([]byte(`{"event":"subscribed","channel":"book","chanId":1,"symbol":"tBTCUSD","prec":"P1"}`))
+	adapter.Decode([]byte(`{"event":"subscribed","channel":"book","chanId":2,"symbol":"tBTCUSD","prec":"R0"}`))
+
+	if _, _, err := adapter.Decode([]byte(`[1,[10000,2]]`)); err == nil {
+		t.Errorf("Expected invalid level error")
+	}
+
+	if _, _, err := adapter.Decode([]byte(`[1,[10000,-2,1]]`)); err == nil {
+		t.Errorf("Expected invalid count error")
+	}
+
+	snapshot, event, err := adapter.Decode([]byte(`[2,[[1,10000,0.5]]]`))
+	if snapshot != nil || event != nil || err != nil {
+		t.Errorf("Expected raw book message to be ignored")
+	}
+
+	// Unsubscribed channel is ignored
+	adapter.Decode([]byte(`{"event":"unsubscribed","status":"OK","chanId":1}`))
+	if _, event, _ := adapter.Decode([]byte(`[1,[10000,1,1]]`)); event != nil {
+		t.Errorf("Expected unsubscribed channel message to be ignored")
+	}
+}
+
+// TestBitfinexAdapterFailedUpdate checks failed update takes its ID and following update reports gap
+func TestBitfinexAdapterFailedUpdate(t *testing.T) {
+	adapter := NewBitfinexAdapter(DefaultLevelDecoder)
+	adapter.Decode([]byte(`{"event":"subscribed","channel":"book","chanId":1,"symbol":"tBTCUSD","prec":"P0"}`))
+
+	ob := New("tBTCUSD", 25)
+	snapshot, _, _ := adapter.Decode([]byte(`[1,[[10000,1,1],[10001,1,-1]]]`))
+	if err := ob.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := adapter.Decode([]byte(`[1,[10000,-2,1]]`)); err == nil {
+		t.Errorf("Expected invalid count error")
+	}
+
+	_, event, _ := adapter.Decode([]byte(`[1,[10000,2,3]]`))
+
+	var gap *ErrSequenceGap
+	if err := ob.ProcessEvent(event); !errors.As(err, &gap) {
+		t.Errorf("Expected sequence gap, got: %v", err)
+	}
+}
+
+// TestBitfinexAdapterBulkUpdates converts level lists following snapshot to single event
+func TestBitfinexAdapterBulkUpdates(t *testing.T) {
+	adapter := NewBitfinexAdapter(LevelDecoder{Instrument: Instrument{PriceExp: -8, SizeExp: -8}})
+	ob := adapter.NewBook("tBTCUSD", 25)
+
+	lines := readFixtureLines(t, "bitfinex/book.jsonl")
+	checkNoErrors(t, replayFixture(t, ob, lines[:4], adapter.Decode).errs)
+
+	// Bid 10000 changes twice, ask 10001 is removed
+	snapshot, event, err := adapter.Decode([]byte(`[17082,[[10000,3,2.5],[10001,0,-1],[10000,4,3]]]`))
+	if err != nil || snapshot != nil || event == nil {
+		t.Fatalf("Expected bulk update event, got: %v", err)
+	}
+
+	if len(event.Bids) != 1 || len(event.Asks) != 1 || event.Bids[0].Count != 4 || !event.Asks[0].Delete {
+		t.Errorf("Invalid bulk update! Got: %+v %+v", event.Bids, event.Asks)
+	}
+
+	if err := ob.ProcessEvent(event); err != nil {
+		t.Fatal(err)
+	}
+
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{1000000000000, 300000000}, {999900000000, 25000000}, {999800000000, 200000000}})
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{1000200000000, 320000000}, {1000300000000, 100000000}})
+
+	// Resubscribed channel starts with snapshot again
+	adapter.Decode(lines[1])
+	if snapshot, _, _ := adapter.Decode(lines[3]); snapshot == nil {
+		t.Errorf("Expected snapshot after resubscribe")
+	}
+}
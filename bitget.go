@@ -0,0 +1,211 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bitgetChecksumDepth levels per side covered by Bitget checksum
+const bitgetChecksumDepth = 25
+
+// bitgetMessage v2 books channel push
+type bitgetMessage struct {
+	Action string `json:"action"`
+	Arg    struct {
+		InstType string `json:"instType"`
+		Channel  string `json:"channel"`
+		InstID   string `json:"instId"`
+	} `json:"arg"`
+	Data []bitgetBook `json:"data"`
+}
+
+// bitgetBook snapshot or update
+type bitgetBook struct {
+	Asks      [][]string `json:"asks"`
+	Bids      [][]string `json:"bids"`
+	Checksum  int32      `json:"checksum"`
+	Seq       int64      `json:"seq"`
+	PrevSeq   int64      `json:"pseq"`
+	Timestamp string     `json:"ts"`
+}
+
+// bitgetText raw "price:size" level text by price, Bitget checksum covers text as sent (e.g. "26275.0")
+type bitgetText struct {
+	asks map[int64]string
+	bids map[int64]string
+}
+
+// BitgetAdapter converts Bitget v2 books channel messages. seq is mapped to FinalUpdateID so updates
+// are checked for ordering, pseq (when sent) to PrevUpdateID so updates are checked for continuity.
+// Bitget checksum is computed over level text as sent, trailing zeros included, so adapter keeps raw
+// level text per symbol and verifies checksum before returning snapshot or update. On mismatch
+// *ErrChecksumMismatch is returned and following updates of symbol fail until new snapshot, channel must
+// then be resubscribed. Level text of all symbols is shared by Decode, so pushes must be decoded from one
+// goroutine.
+type BitgetAdapter struct {
+	Decoder LevelDecoder
+
+	text map[string]*bitgetText
+}
+
+// NewBitgetAdapter creates new struct instance of *BitgetAdapter
+func NewBitgetAdapter(decoder LevelDecoder) *BitgetAdapter {
+	return &BitgetAdapter{
+		Decoder: decoder,
+		text:    make(map[string]*bitgetText),
+	}
+}
+
+// NewBook creates book using adapter instrument
+func (a *BitgetAdapter) NewBook(symbol string, pruneThreshold int, options ...Option) *OrderBook {
+	return a.Decoder.newBook(symbol, pruneThreshold, options)
+}
+
+// Decode converts snapshot push to DepthSnapshot and update push to DepthEvent after verifying checksum.
+// Other messages (subscribe responses, pong, other channels) return nil snapshot and event.
+func (a *BitgetAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	var msg bitgetMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("bitget books: %w", err)
+	}
+
+	if msg.Arg.Channel != "books" || (msg.Action != "snapshot" && msg.Action != "update") {
+		return nil, nil, nil
+	}
+
+	symbol := msg.Arg.InstID
+
+	// Bitget wraps books push in one element data array
+	if len(msg.Data) != 1 {
+		return nil, nil, fmt.Errorf("bitget books(%s): expected 1 book, got %d", symbol, len(msg.Data))
+	}
+	book := &msg.Data[0]
+
+	asks, err := a.Decoder.AskLevels(book.Asks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bitget books(%s) %d: %w", symbol, book.Seq, err)
+	}
+
+	bids, err := a.Decoder.BidLevels(book.Bids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bitget books(%s) %d: %w", symbol, book.Seq, err)
+	}
+
+	if err := a.verify(symbol, msg.Action == "snapshot", book, asks, bids); err != nil {
+		return nil, nil, err
+	}
+
+	if msg.Action == "snapshot" {
+		return &DepthSnapshot{
+			Symbol:       symbol,
+			LastUpdateID: book.Seq,
+			Asks:         asks,
+			Bids:         bids,
+		}, nil, nil
+	}
+
+	event := &DepthEvent{
+		Symbol:        symbol,
+		FinalUpdateID: book.Seq,
+		PrevUpdateID:  book.PrevSeq,
+		Asks:          asks,
+		Bids:          bids,
+	}
+
+	if book.Timestamp != "" {
+		ms, err := strconv.ParseInt(book.Timestamp, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("bitget books(%s) %d: %w", symbol, book.Seq, err)
+		}
+		event.Timestamp = time.UnixMilli(ms)
+	}
+
+	return nil, event, nil
+}
+
+// verify applies push level text to symbol text book and checks push checksum
+func (a *BitgetAdapter) verify(symbol string, snapshot bool, book *bitgetBook, asks []*Ask, bids []*Bid) error {
+	text, ok := a.text[symbol]
+	if snapshot || !ok {
+		if !snapshot {
+			return fmt.Errorf("bitget books(%s) %d: update without snapshot, resubscribe channel", symbol, book.Seq)
+		}
+
+		text = &bitgetText{asks: make(map[int64]string), bids: make(map[int64]string)}
+		a.text[symbol] = text
+	}
+
+	// Decoded levels follow push level order
+	for i, ask := range asks {
+		setBitgetText(text.asks, ask.Price, ask.Delete, book.Asks[i])
+	}
+
+	for i, bid := range bids {
+		setBitgetText(text.bids, bid.Price, bid.Delete, book.Bids[i])
+	}
+
+	checksum := text.checksum()
+	if checksum == uint32(book.Checksum) {
+		return nil
+	}
+
+	delete(a.text, symbol)
+
+	return &ErrChecksumMismatch{
+		Symbol:   symbol,
+		UpdateID: book.Seq,
+		Expected: uint32(book.Checksum),
+		Actual:   checksum,
+	}
+}
+
+// setBitgetText sets or removes level text
+func setBitgetText(side map[int64]string, price int64, remove bool, level []string) {
+	if remove {
+		delete(side, price)
+		return
+	}
+
+	side[price] = level[0] + ":" + level[1]
+}
+
+// checksum computes CRC32 of top levels interleaved as bidPrice:bidSize:askPrice:askSize, signed
+func (t *bitgetText) checksum() uint32 {
+	asks := sortedPrices(t.asks, false)
+	bids := sortedPrices(t.bids, true)
+
+	var parts []string
+	for i := 0; i < bitgetChecksumDepth; i++ {
+		if i < len(bids) {
+			parts = append(parts, t.bids[bids[i]])
+		}
+
+		if i < len(asks) {
+			parts = append(parts, t.asks[asks[i]])
+		}
+	}
+
+	return crc32.ChecksumIEEE([]byte(strings.Join(parts, ":")))
+}
+
+// sortedPrices returns side prices in priority order
+func sortedPrices(side map[int64]string, desc bool) []int64 {
+	prices := make([]int64, 0, len(side))
+	for price := range side {
+		prices = append(prices, price)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		if desc {
+			return prices[i] > prices[j]
+		}
+		return prices[i] < prices[j]
+	})
+
+	return prices
+}
@@ -0,0 +1,41 @@
+package orderbook
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestBitgetAdapter applies hand-written books pushes, last push has invalid checksum
+func TestBitgetAdapter(t *testing.T) {
+	adapter := NewBitgetAdapter(DefaultLevelDecoder)
+	ob := adapter.NewBook("BTCUSDT", 150)
+
+	lines := readFixtureLines(t, "bitget/books.jsonl")
+	checkNoErrors(t, replayFixture(t, ob, lines[:len(lines)-1], adapter.Decode).errs)
+
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{2627495000000, 100000}, {2627500000000, 50000}, {2627630000000, 1200000}})
+
+	var mismatch *ErrChecksumMismatch
+	if _, event, err := adapter.Decode(lines[len(lines)-1]); !errors.As(err, &mismatch) || event != nil || mismatch.UpdateID != 140 {
+		t.Errorf("Expected checksum mismatch, got: %v", err)
+	}
+
+	// Updates fail until channel is resubscribed
+	if _, _, err := adapter.Decode(lines[2]); err == nil {
+		t.Errorf("Expected update without snapshot error")
+	}
+}
+
+// TestBitgetAdapterRawChecksum checks checksum covers level text as sent
+func TestBitgetAdapterRawChecksum(t *testing.T) {
+	adapter := NewBitgetAdapter(DefaultLevelDecoder)
+
+	// Checksum of trimmed text ("26275:0.05") does not match push text ("26275.0:0.0500")
+	snapshot := bytes.Replace(readFixtureLines(t, "bitget/books.jsonl")[1], []byte("-1350270743"), []byte("-1464557281"), 1)
+
+	var mismatch *ErrChecksumMismatch
+	if _, _, err := adapter.Decode(snapshot); !errors.As(err, &mismatch) {
+		t.Errorf("Expected checksum mismatch, got: %v", err)
+	}
+}
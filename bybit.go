@@ -0,0 +1,79 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// bybitMessage v5 orderbook topic push
+type bybitMessage struct {
+	Topic     string `json:"topic"`
+	Type      string `json:"type"`
+	Timestamp int64  `json:"ts"`
+	Data      struct {
+		Symbol   string      `json:"s"`
+		Bids     [][2]string `json:"b"`
+		Asks     [][2]string `json:"a"`
+		UpdateID int64       `json:"u"`
+		Seq      int64       `json:"seq"`
+	} `json:"data"`
+}
+
+// BybitAdapter converts Bybit v5 orderbook.{depth}.{symbol} messages. Update ID u increases by one per
+// delta, deltas are checked for continuity by OrderBook. Snapshots always replace book, snapshot with u == 1
+// is sent after service restart and resets update IDs.
+type BybitAdapter struct {
+	Decoder LevelDecoder
+}
+
+// NewBybitAdapter creates new struct instance of *BybitAdapter
+func NewBybitAdapter(decoder LevelDecoder) *BybitAdapter {
+	return &BybitAdapter{
+		Decoder: decoder,
+	}
+}
+
+// Decode converts snapshot message to DepthSnapshot and delta message to DepthEvent, other messages
+// (subscribe responses, pong, other topics) return nil snapshot and event
+func (a *BybitAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	var msg bybitMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("bybit orderbook: %w", err)
+	}
+
+	if !strings.HasPrefix(msg.Topic, "orderbook.") || (msg.Type != "snapshot" && msg.Type != "delta") {
+		return nil, nil, nil
+	}
+
+	book := &msg.Data
+
+	asks, err := a.Decoder.Asks(book.Asks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bybit orderbook(%s) %d: %w", book.Symbol, book.UpdateID, err)
+	}
+
+	bids, err := a.Decoder.Bids(book.Bids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bybit orderbook(%s) %d: %w", book.Symbol, book.UpdateID, err)
+	}
+
+	if msg.Type == "snapshot" {
+		return &DepthSnapshot{
+			Symbol:       book.Symbol,
+			LastUpdateID: book.UpdateID,
+			Asks:         asks,
+			Bids:         bids,
+		}, nil, nil
+	}
+
+	return nil, &DepthEvent{
+		Symbol:        book.Symbol,
+		FirstUpdateID: book.UpdateID,
+		FinalUpdateID: book.UpdateID,
+		Asks:          asks,
+		Bids:          bids,
+		Timestamp:     time.UnixMilli(msg.Timestamp),
+	}, nil
+}
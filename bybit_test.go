@@ -0,0 +1,27 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBybitAdapter applies hand-written orderbook messages including service restart snapshot and gap
+func TestBybitAdapter(t *testing.T) {
+	adapter := NewBybitAdapter(DefaultLevelDecoder)
+	ob := New("BTCUSDT", 50)
+
+	errs := replayFixture(t, ob, readFixtureLines(t, "bybit/orderbook.jsonl"), adapter.Decode).errs
+	if len(errs) != 6 {
+		t.Fatalf("Invalid message count! Expected: %d, got: %d", 6, len(errs))
+	}
+	checkNoErrors(t, errs[:5])
+
+	// Restart snapshot reset update IDs, u 3 is missing
+	var gap *ErrSequenceGap
+	if !errors.As(errs[5], &gap) || gap.Expected != 3 || ob.Loaded {
+		t.Errorf("Expected sequence gap, got: %v", errs[5])
+	}
+
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{1650100000000, 400000}})
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{1650000000000, 300000}, {1649950000000, 200000}})
+}
@@ -0,0 +1,204 @@
+package orderbook
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// ChecksumScheme exchange book checksum algorithm
+type ChecksumScheme int
+
+const (
+	// ChecksumKraken CRC32 of top 10 asks then top 10 bids, price and size formatted with instrument
+	// decimals, decimal point and leading zeros removed, concatenated without separator
+	ChecksumKraken ChecksumScheme = iota + 1
+	// ChecksumOKX CRC32 of top 25 levels interleaved as bidPrice:bidSize:askPrice:askSize, signed
+	ChecksumOKX
+	// ChecksumBitfinex CRC32 of top 25 levels interleaved as bidPrice:bidAmount:askPrice:-askAmount, signed
+	ChecksumBitfinex
+)
+
+// ChecksumConfig configures book checksum
+type ChecksumConfig struct {
+	Scheme ChecksumScheme
+	// Depth levels per side, defaults to scheme depth
+	Depth int
+	// PriceDecimals and SizeDecimals digits after decimal point, used by ChecksumKraken
+	PriceDecimals int
+	SizeDecimals  int
+}
+
+// depth returns levels per side
+func (c ChecksumConfig) depth() int {
+	if c.Depth > 0 {
+		return c.Depth
+	}
+
+	if c.Scheme == ChecksumKraken {
+		return 10
+	}
+	return 25
+}
+
+// ErrChecksumMismatch is returned when book checksum differs from event checksum, book is marked not loaded
+type ErrChecksumMismatch struct {
+	Symbol   string
+	UpdateID int64
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ErrChecksumMismatch) Error() string {
+	return fmt.Sprintf("checksum mismatch(%s) %d: expected %d, got %d", e.Symbol, e.UpdateID, e.Expected, e.Actual)
+}
+
+// WithChecksum verifies checksums carried on depth events
+func WithChecksum(config ChecksumConfig) Option {
+	return func(ob *OrderBook) {
+		ob.checksum = &config
+	}
+}
+
+// Checksum computes book checksum, signed schemes return int32 bit pattern
+func (ob *OrderBook) Checksum(config ChecksumConfig) uint32 {
+	return ob.sides().Checksum(config)
+}
+
+// Checksum computes sides checksum
+func (ob bookSides) Checksum(config ChecksumConfig) uint32 {
+	depth := config.depth()
+	asks := topLevels(ob.Asks, depth)
+	bids := topLevels(ob.Bids, depth)
+
+	var b strings.Builder
+
+	switch config.Scheme {
+	case ChecksumKraken:
+		for _, levels := range [][]ListNode{asks, bids} {
+			for _, level := range levels {
+				b.WriteString(krakenChecksumString(ob.Instrument.FormatPrice(level.Price), config.PriceDecimals))
+				b.WriteString(krakenChecksumString(ob.Instrument.FormatSize(level.Size), config.SizeDecimals))
+			}
+		}
+	case ChecksumOKX, ChecksumBitfinex:
+		for i := 0; i < depth; i++ {
+			if i < len(bids) {
+				writeChecksumLevel(&b, ob.Instrument, bids[i].Price, bids[i].Size, config.Scheme)
+			}
+
+			if i < len(asks) {
+				writeChecksumLevel(&b, ob.Instrument, asks[i].Price, -asks[i].Size, config.Scheme)
+			}
+		}
+	}
+
+	return crc32.ChecksumIEEE([]byte(b.String()))
+}
+
+// verifyChecksum compares book checksum against update checksum
+func (ob *OrderBook) verifyChecksum(updateID int64, expected uint32, hasChecksum bool) error {
+	if ob.checksum == nil || !hasChecksum {
+		return nil
+	}
+
+	checksum := ob.Checksum(*ob.checksum)
+	if checksum == expected {
+		return nil
+	}
+
+	ob.Loaded = false
+
+	return &ErrChecksumMismatch{
+		Symbol:   ob.Symbol,
+		UpdateID: updateID,
+		Expected: expected,
+		Actual:   checksum,
+	}
+}
+
+// topLevels copies first depth side levels
+func topLevels(side SideReader, depth int) []ListNode {
+	levels := make([]ListNode, 0, depth)
+	side.Each(func(node *ListNode) bool {
+		levels = append(levels, ListNode{Price: node.Price, Size: node.Size})
+		return len(levels) < depth
+	})
+	return levels
+}
+
+// writeChecksumLevel writes colon separated price and size, size sign is kept only for Bitfinex asks
+func writeChecksumLevel(b *strings.Builder, instrument Instrument, price, size int64, scheme ChecksumScheme) {
+	if b.Len() > 0 {
+		b.WriteByte(':')
+	}
+
+	negative := size < 0
+	if negative {
+		size = -size
+	}
+
+	priceStr := trimDecimalString(instrument.FormatPrice(price))
+	sizeStr := trimDecimalString(instrument.FormatSize(size))
+
+	if scheme == ChecksumBitfinex {
+		priceStr = jsNumberString(priceStr)
+		sizeStr = jsNumberString(sizeStr)
+		if negative {
+			sizeStr = "-" + sizeStr
+		}
+	}
+
+	b.WriteString(priceStr)
+	b.WriteByte(':')
+	b.WriteString(sizeStr)
+}
+
+// krakenChecksumString formats decimal string with decimals digits, decimal point and leading zeros removed
+func krakenChecksumString(value string, decimals int) string {
+	parts := strings.SplitN(value, ".", 2)
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = parts[1]
+	}
+
+	if len(fraction) > decimals {
+		fraction = fraction[:decimals]
+	} else {
+		fraction += strings.Repeat("0", decimals-len(fraction))
+	}
+
+	digits := strings.TrimLeft(parts[0]+fraction, "0")
+	if digits == "" {
+		return "0"
+	}
+	return digits
+}
+
+// trimDecimalString removes trailing fraction zeros and decimal point
+func trimDecimalString(value string) string {
+	if !strings.Contains(value, ".") {
+		return value
+	}
+
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
+
+// jsNumberString formats decimal string as JavaScript Number.toString does, values below 1e-6 use exponent
+func jsNumberString(value string) string {
+	if !strings.HasPrefix(value, "0.000000") {
+		return value
+	}
+
+	digits := strings.TrimLeft(value[2:], "0")
+	exp := len(value) - 2 - len(digits) + 1
+
+	mantissa := digits[:1]
+	if len(digits) > 1 {
+		mantissa += "." + digits[1:]
+	}
+
+	return mantissa + "e-" + strconv.Itoa(exp)
+}
@@ -0,0 +1,124 @@
+package orderbook
+
+import (
+	"errors"
+	"hash/crc32"
+	"testing"
+)
+
+// TestChecksumKraken checks Kraken level formatting
+func TestChecksumKraken(t *testing.T) {
+	ob := New("XBTUSD", 100)
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks: []*Ask{
+			{Price: 5005000, Quantity: 5},
+			{Price: 5010000, Quantity: 1000000},
+		},
+		Bids: []*Bid{
+			{Price: 5000000, Quantity: 1500000},
+		},
+	}, nil)
+
+	config := ChecksumConfig{Scheme: ChecksumKraken, PriceDecimals: 5, SizeDecimals: 8}
+
+	// 0.05005 0.00000500, 0.05010 1.00000000, 0.05000 1.50000000
+	expected := crc32.ChecksumIEEE([]byte("5005" + "500" + "5010" + "100000000" + "5000" + "150000000"))
+
+	if checksum := ob.Checksum(config); checksum != expected {
+		t.Errorf("Invalid checksum! Expected: %d, got: %d", expected, checksum)
+	}
+}
+
+// TestChecksumOKX checks OKX interleaved level formatting
+func TestChecksumOKX(t *testing.T) {
+	ob := New("BTC-USDT", 100)
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks: []*Ask{
+			{Price: 336680000000, Quantity: 9000000},
+			{Price: 336800000000, Quantity: 8000000},
+			{Price: 336900000000, Quantity: 500000},
+		},
+		Bids: []*Bid{
+			{Price: 336610000000, Quantity: 7000000},
+			{Price: 336600000000, Quantity: 6000000},
+		},
+	}, nil)
+
+	expected := crc32.ChecksumIEEE([]byte("3366.1:7:3366.8:9:3366:6:3368:8:3369:0.5"))
+
+	if checksum := ob.Checksum(ChecksumConfig{Scheme: ChecksumOKX}); checksum != expected {
+		t.Errorf("Invalid checksum! Expected: %d, got: %d", int32(expected), int32(checksum))
+	}
+}
+
+// TestChecksumBitfinex checks Bitfinex signed amounts and verification on events
+func TestChecksumBitfinex(t *testing.T) {
+	config := ChecksumConfig{Scheme: ChecksumBitfinex}
+
+	ob := New("tBTCUSD", 100, WithChecksum(config))
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 652330000000, Quantity: 1200000}},
+		Bids:         []*Bid{{Price: 652320000000, Quantity: 500000}},
+	}, nil)
+
+	expected := crc32.ChecksumIEEE([]byte("6523.2:0.5:6523.3:-1.2"))
+	if checksum := ob.Checksum(config); checksum != expected {
+		t.Errorf("Invalid checksum! Expected: %d, got: %d", int32(expected), int32(checksum))
+	}
+
+	// Matching checksum
+	err := ob.ProcessEvent(&DepthEvent{
+		FinalUpdateID: 2,
+		Bids:          []*Bid{{Price: 652320000000, Quantity: 10}},
+		Checksum:      crc32.ChecksumIEEE([]byte("6523.2:0.00001:6523.3:-1.2")),
+		HasChecksum:   true,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Mismatch marks book not loaded
+	err = ob.ProcessEvent(&DepthEvent{
+		FinalUpdateID: 3,
+		Bids:          []*Bid{{Price: 652320000000, Quantity: 20}},
+		Checksum:      expected,
+		HasChecksum:   true,
+	})
+
+	var mismatch *ErrChecksumMismatch
+	if !errors.As(err, &mismatch) || ob.Loaded {
+		t.Errorf("Expected checksum mismatch, got: %v", err)
+	}
+
+	// Dust amounts are formatted in exponent notation before sign is added
+	ob = New("tBTCUSD", 100, WithChecksum(config), WithInstrument(Instrument{PriceExp: PriceDecimalExp, SizeExp: -8}))
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 652330000000, Quantity: 12}},
+		Bids:         []*Bid{{Price: 652320000000, Quantity: 1}},
+	}, nil)
+
+	expected = crc32.ChecksumIEEE([]byte("6523.2:1e-8:6523.3:-1.2e-7"))
+	if checksum := ob.Checksum(config); checksum != expected {
+		t.Errorf("Invalid dust checksum! Expected: %d, got: %d", int32(expected), int32(checksum))
+	}
+}
+
+// TestJSNumberString checks exponent formatting of small values
+func TestJSNumberString(t *testing.T) {
+	cases := map[string]string{
+		"0.00000012": "1.2e-7",
+		"0.00000001": "1e-8",
+		"0.000001":   "0.000001",
+		"6523.2":     "6523.2",
+	}
+
+	for value, expected := range cases {
+		if result := jsNumberString(value); result != expected {
+			t.Errorf("Invalid number string for %s! Expected: %s, got: %s", value, expected, result)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// coinbaseMessage level2 channel snapshot and l2update message
+type coinbaseMessage struct {
+	Type      string      `json:"type"`
+	ProductID string      `json:"product_id"`
+	Time      string      `json:"time"`
+	Bids      [][2]string `json:"bids"`
+	Asks      [][2]string `json:"asks"`
+	Changes   [][3]string `json:"changes"`
+}
+
+// CoinbaseAdapter converts Coinbase Exchange level2 channel messages. Coinbase sends no update IDs,
+// adapter assigns synthetic per product IDs increasing by one per message so OrderBook ordering and
+// sequence checks still apply. IDs count l2update messages in decode order, so a feed must be decoded by
+// one goroutine in the order it was received. l2update failing to decode still takes its ID, following
+// update reports sequence gap.
+type CoinbaseAdapter struct {
+	Decoder LevelDecoder
+
+	ids map[string]int64
+}
+
+// NewCoinbaseAdapter creates new struct instance of *CoinbaseAdapter
+func NewCoinbaseAdapter(decoder LevelDecoder) *CoinbaseAdapter {
+	return &CoinbaseAdapter{
+		Decoder: decoder,
+		ids:     make(map[string]int64),
+	}
+}
+
+// Decode converts snapshot message to DepthSnapshot and l2update message to DepthEvent,
+// other message types (subscriptions, heartbeat, ...) return nil snapshot and event
+func (a *CoinbaseAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	var msg coinbaseMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("coinbase level2: %w", err)
+	}
+
+	switch msg.Type {
+	case "snapshot":
+		snapshot, err := a.snapshot(&msg)
+		return snapshot, nil, err
+	case "l2update":
+		event, err := a.event(&msg)
+		return nil, event, err
+	}
+
+	return nil, nil, nil
+}
+
+// nextID returns next synthetic product update ID
+func (a *CoinbaseAdapter) nextID(product string) int64 {
+	a.ids[product]++
+	return a.ids[product]
+}
+
+// snapshot converts snapshot message
+func (a *CoinbaseAdapter) snapshot(msg *coinbaseMessage) (*DepthSnapshot, error) {
+	asks, err := a.Decoder.Asks(msg.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("coinbase snapshot(%s): %w", msg.ProductID, err)
+	}
+
+	bids, err := a.Decoder.Bids(msg.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("coinbase snapshot(%s): %w", msg.ProductID, err)
+	}
+
+	return &DepthSnapshot{
+		Symbol:       msg.ProductID,
+		LastUpdateID: a.nextID(msg.ProductID),
+		Asks:         asks,
+		Bids:         bids,
+	}, nil
+}
+
+// event converts l2update message, changes are [side, price, size] with side "buy" or "sell"
+func (a *CoinbaseAdapter) event(msg *coinbaseMessage) (*DepthEvent, error) {
+	id := a.nextID(msg.ProductID)
+	event := &DepthEvent{
+		Symbol:        msg.ProductID,
+		FirstUpdateID: id,
+		FinalUpdateID: id,
+	}
+
+	if msg.Time != "" {
+		timestamp, err := time.Parse(time.RFC3339Nano, msg.Time)
+		if err != nil {
+			return nil, fmt.Errorf("coinbase l2update(%s): %w", msg.ProductID, err)
+		}
+		event.Timestamp = timestamp
+	}
+
+	for _, change := range msg.Changes {
+		switch change[0] {
+		case "buy":
+			bid, err := a.Decoder.Bid(change[1], change[2])
+			if err != nil {
+				return nil, fmt.Errorf("coinbase l2update(%s): %w", msg.ProductID, err)
+			}
+			event.Bids = append(event.Bids, bid)
+		case "sell":
+			ask, err := a.Decoder.Ask(change[1], change[2])
+			if err != nil {
+				return nil, fmt.Errorf("coinbase l2update(%s): %w", msg.ProductID, err)
+			}
+			event.Asks = append(event.Asks, ask)
+		default:
+			return nil, fmt.Errorf("coinbase l2update(%s): invalid side %q", msg.ProductID, change[0])
+		}
+	}
+
+	return event, nil
+}
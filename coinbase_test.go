@@ -0,0 +1,61 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCoinbaseAdapter applies hand-written level2 messages
+func TestCoinbaseAdapter(t *testing.T) {
+	// Coinbase sizes carry 8 decimals
+	adapter := NewCoinbaseAdapter(LevelDecoder{Instrument: Instrument{PriceExp: -8, SizeExp: -8}})
+
+	ob := New("BTC-USD", 100, WithInstrument(adapter.Decoder.Instrument))
+
+	r := replayFixture(t, ob, readFixtureLines(t, "coinbase/level2.jsonl"), adapter.Decode)
+	checkNoErrors(t, r.errs)
+
+	if r.events != 2 || ob.LastUpdateID != 3 {
+		t.Errorf("Invalid events! Expected: %d events up to update ID %d, got: %d up to %d", 2, 3, r.events, ob.LastUpdateID)
+	}
+
+	expectedTime := time.Date(2019, 8, 14, 20, 42, 27, 365000000, time.UTC)
+	if bbo := ob.BBO(); bbo.AskPrice != 1010290000000 || bbo.BidPrice != 1010180000000 {
+		t.Errorf("Invalid BBO! Got: %+v", bbo)
+	}
+
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{1010290000000, 25000000}, {1010300000000, 250000000}})
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{1010180000000, 16256700}, {1010110000000, 45054140}})
+
+	// Resubscription snapshot continues IDs
+	snapshot, _, _ := adapter.Decode(readFixtureLines(t, "coinbase/level2.jsonl")[1])
+	if snapshot.LastUpdateID != 4 {
+		t.Errorf("Invalid snapshot update ID! Expected: %d, got: %d", 4, snapshot.LastUpdateID)
+	}
+
+	if err := ob.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, event, _ := adapter.Decode([]byte(`{"type":"l2update","product_id":"BTC-USD","time":"2019-08-14T20:42:27.365Z","changes":[]}`))
+	if !event.Timestamp.Equal(expectedTime) || event.FirstUpdateID != 5 {
+		t.Errorf("Invalid event! Got: %+v", event)
+	}
+
+	if err := ob.ProcessEvent(event); err != nil {
+		t.Error(err)
+	}
+
+	if _, _, err := adapter.Decode([]byte(`{"type":"l2update","product_id":"BTC-USD","changes":[["hold","1","1"]]}`)); err == nil {
+		t.Errorf("Expected invalid side error")
+	}
+
+	// Failed update took its ID
+	_, event, _ = adapter.Decode([]byte(`{"type":"l2update","product_id":"BTC-USD","changes":[]}`))
+
+	var gap *ErrSequenceGap
+	if err := ob.ProcessEvent(event); !errors.As(err, &gap) {
+		t.Errorf("Expected sequence gap, got: %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package orderbook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConcurrentOrderBook wraps OrderBook for concurrent use, updates hold exclusive lock and reads shared lock.
+// Subscription updates are delivered after lock is released, in update order, so subscribers blocking
+// updates (BackPressureBlock) may read book.
+type ConcurrentOrderBook struct {
+	mu   sync.RWMutex
+	book *OrderBook
+
+	// Delivery tickets are taken under mu, delivered is signaled once ticket is done
+	deliveryMu sync.Mutex
+	delivered  *sync.Cond
+	nextTicket uint64
+	doneTicket uint64
+}
+
+// NewConcurrent creates new struct instance of *ConcurrentOrderBook
+func NewConcurrent(symbol string, pruneThreshold int, options ...Option) *ConcurrentOrderBook {
+	return WrapConcurrent(New(symbol, pruneThreshold, options...))
+}
+
+// WrapConcurrent wraps book, book must not be used directly afterwards
+func WrapConcurrent(ob *OrderBook) *ConcurrentOrderBook {
+	ob.deferDelivery = true
+
+	c := &ConcurrentOrderBook{
+		book: ob,
+	}
+	c.delivered = sync.NewCond(&c.deliveryMu)
+
+	return c
+}
+
+// Read calls fn with book under shared lock, fn must not modify book
+func (c *ConcurrentOrderBook) Read(fn func(ob *OrderBook)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fn(c.book)
+}
+
+// Write calls fn with book under exclusive lock, subscription updates are delivered after unlock
+func (c *ConcurrentOrderBook) Write(fn func(ob *OrderBook)) {
+	ticket, pending := c.write(fn)
+	if len(pending) > 0 {
+		c.deliver(ticket, pending)
+	}
+}
+
+// write calls fn under exclusive lock, returns updates to deliver with their delivery ticket
+func (c *ConcurrentOrderBook) write(fn func(ob *OrderBook)) (uint64, []pendingUpdate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fn(c.book)
+
+	pending := c.book.takePending()
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	c.deliveryMu.Lock()
+	defer c.deliveryMu.Unlock()
+
+	ticket := c.nextTicket
+	c.nextTicket++
+
+	return ticket, pending
+}
+
+// deliver sends pending updates once updates of earlier tickets are delivered
+func (c *ConcurrentOrderBook) deliver(ticket uint64, pending []pendingUpdate) {
+	c.deliveryMu.Lock()
+	for c.doneTicket != ticket {
+		c.delivered.Wait()
+	}
+	c.deliveryMu.Unlock()
+
+	deliver(pending)
+
+	c.deliveryMu.Lock()
+	c.doneTicket++
+	c.delivered.Broadcast()
+	c.deliveryMu.Unlock()
+}
+
+// View returns latest published book version without locking, nil unless book was created WithViews
+func (c *ConcurrentOrderBook) View() *BookView {
+	return c.book.View()
+}
+
+// ProcessSnapshot replaces book with depth snapshot
+func (c *ConcurrentOrderBook) ProcessSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) error {
+	var err error
+	c.Write(func(ob *OrderBook) {
+		err = ob.ProcessSnapshot(snapshot, eventBuffer)
+	})
+
+	return err
+}
+
+// ProcessEvent processes depth update event
+func (c *ConcurrentOrderBook) ProcessEvent(event *DepthEvent) error {
+	var err error
+	c.Write(func(ob *OrderBook) {
+		err = ob.ProcessEvent(event)
+	})
+
+	return err
+}
+
+// Clear cache
+func (c *ConcurrentOrderBook) Clear() {
+	c.Write(func(ob *OrderBook) {
+		ob.Clear()
+	})
+}
+
+// Subscribe registers subscription, see OrderBook.Subscribe
+func (c *ConcurrentOrderBook) Subscribe(buffer int, policy BackPressure) *Subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.book.Subscribe(buffer, policy)
+}
+
+// SubscribeFunc registers callback subscription, see OrderBook.SubscribeFunc
+func (c *ConcurrentOrderBook) SubscribeFunc(buffer int, policy BackPressure, fn func(update *BookUpdate)) *Subscription {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.book.SubscribeFunc(buffer, policy, fn)
+}
+
+// Symbol returns book symbol
+func (c *ConcurrentOrderBook) Symbol() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Symbol
+}
+
+// Loaded reports whether book is loaded
+func (c *ConcurrentOrderBook) Loaded() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Loaded
+}
+
+// LastUpdateID returns last applied update ID
+func (c *ConcurrentOrderBook) LastUpdateID() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.LastUpdateID
+}
+
+// UpdatedAt returns last update time
+func (c *ConcurrentOrderBook) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.UpdatedAt
+}
+
+// Instrument returns book instrument
+func (c *ConcurrentOrderBook) Instrument() Instrument {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Instrument
+}
+
+// BBO returns current best bid and offer
+func (c *ConcurrentOrderBook) BBO() BBO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.BBO()
+}
+
+// Crossed reports whether book was crossed or locked after last update
+func (c *ConcurrentOrderBook) Crossed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Crossed()
+}
+
+// Dropped returns number of levels sides dropped on their own, see OrderBook.Dropped
+func (c *ConcurrentOrderBook) Dropped() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Dropped()
+}
+
+// Checksum computes book checksum
+func (c *ConcurrentOrderBook) Checksum(config ChecksumConfig) uint32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.Checksum(config)
+}
+
+// OrderBookAskConversion bid ask conversion
+func (c *ConcurrentOrderBook) OrderBookAskConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.OrderBookAskConversion(amount)
+}
+
+// OrderBookBidConversion bid conversion
+func (c *ConcurrentOrderBook) OrderBookBidConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.OrderBookBidConversion(amount)
+}
+
+// OrderBooAskReverseConversion ask reverse conversion
+func (c *ConcurrentOrderBook) OrderBooAskReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.OrderBooAskReverseConversion(amount)
+}
+
+// OrderBookBidReverseConversion bid reverse conversion
+func (c *ConcurrentOrderBook) OrderBookBidReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.OrderBookBidReverseConversion(amount)
+}
+
+// GetBuyOrderBookDepthRequirement get orderbook depth fill requirement for buy order
+func (c *ConcurrentOrderBook) GetBuyOrderBookDepthRequirement(maxPrice decimal.Decimal, amount decimal.Decimal) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.GetBuyOrderBookDepthRequirement(maxPrice, amount)
+}
+
+// GetSellOrderBookDepthRequirement get orderbook depth fill requirement for sell order
+func (c *ConcurrentOrderBook) GetSellOrderBookDepthRequirement(minPrice decimal.Decimal, amount decimal.Decimal) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.GetSellOrderBookDepthRequirement(minPrice, amount)
+}
+
+// GetMarketPrice return current market price
+func (c *ConcurrentOrderBook) GetMarketPrice() (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.GetMarketPrice()
+}
+
+// GetFirstAskPrice returns first ask price
+func (c *ConcurrentOrderBook) GetFirstAskPrice() (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.GetFirstAskPrice()
+}
+
+// GetFirstBidPrice returns first bid price
+func (c *ConcurrentOrderBook) GetFirstBidPrice() (decimal.Decimal, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.book.GetFirstBidPrice()
+}
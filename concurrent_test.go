@@ -0,0 +1,102 @@
+package orderbook
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestConcurrentOrderBook hammers book from writer and reader goroutines, run with -race
+func TestConcurrentOrderBook(t *testing.T) {
+	const events = 500
+	const readers = 8
+
+	options := map[string][]Option{
+		"skiplist": nil,
+		"ladder":   {WithLadder(100000000, 256)},
+	}
+
+	for name, opts := range options {
+		book := NewConcurrent("BTCUSDT", 10, opts...)
+		if err := book.ProcessSnapshot(testSnapshot(), nil); err != nil {
+			t.Error(err)
+			continue
+		}
+
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+
+		// Writer sets every level to same size per event
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done)
+
+			snapshot := testSnapshot()
+			for i := int64(1); i <= events; i++ {
+				event := &DepthEvent{
+					Symbol:        "BTCUSDT",
+					FirstUpdateID: 100 + i,
+					FinalUpdateID: 100 + i,
+				}
+
+				for _, ask := range snapshot.Asks {
+					event.Asks = append(event.Asks, &Ask{Price: ask.Price, Quantity: i})
+				}
+
+				for _, bid := range snapshot.Bids {
+					event.Bids = append(event.Bids, &Bid{Price: bid.Price, Quantity: i})
+				}
+
+				if err := book.ProcessEvent(event); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+
+		// Readers must never see half applied event
+		for r := 0; r < readers; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					book.Read(func(ob *OrderBook) {
+						first, _ := ob.Asks.Front()
+						size := first.Size
+
+						ob.Asks.Each(func(node *ListNode) bool {
+							if node.Size != size {
+								t.Errorf("%s: Half applied event! Expected size: %d, got: %d", name, size, node.Size)
+							}
+							return true
+						})
+					})
+
+					if _, err := book.GetMarketPrice(); err != nil {
+						t.Error(err)
+					}
+
+					book.OrderBookBidConversion(decimal.NewFromInt(1))
+					book.OrderBooAskReverseConversion(decimal.NewFromInt(1))
+					book.Checksum(ChecksumConfig{Scheme: ChecksumOKX})
+					book.LastUpdateID()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if book.LastUpdateID() != 100+events {
+			t.Errorf("%s: Invalid update ID! Expected: %d, got: %d", name, 100+events, book.LastUpdateID())
+		}
+	}
+}
@@ -6,8 +6,15 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// bookSides pairs ask and bid sides, conversion and price functions are shared by OrderBook and BookView
+type bookSides struct {
+	Asks       SideReader
+	Bids       SideReader
+	Instrument Instrument
+}
+
 // OrderBookAskConversion bid ask conversion
-func (ob *OrderBook) OrderBookAskConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+func (ob bookSides) OrderBookAskConversion(amount decimal.Decimal) (decimal.Decimal, error) {
 	if amount.IsZero() {
 		return decimal.Decimal{}, nil
 	}
@@ -19,24 +26,30 @@ func (ob *OrderBook) OrderBookAskConversion(amount decimal.Decimal) (decimal.Dec
 	var quantity decimal.Decimal
 	var exchangeableAmount decimal.Decimal
 
+	var result decimal.Decimal
+	filled := false
+
 	// Iterate over asks
-	ask, _ := ob.Asks.Front()
-	for ask != nil {
-		price = decimal.New(ask.Price, PriceDecimalExp)
-		quantity = decimal.New(ask.Size, SizeDecimalExp)
+	ob.Asks.Each(func(ask *ListNode) bool {
+		price = ob.Instrument.Price(ask.Price)
+		quantity = ob.Instrument.Size(ask.Size)
 
 		exchangeableAmount = quantity.Mul(price)
 		if exchangeableAmount.LessThan(amountFrom) {
 			// Fill
 			amountFrom = amountFrom.Sub(exchangeableAmount)
 			amountTo = amountTo.Add(quantity)
-		} else {
-			// Last fill
-			return amountTo.Add(amountFrom.Div(price)), nil
+			return true
 		}
 
-		// Get next
-		ask = ask.next
+		// Last fill
+		result = amountTo.Add(amountFrom.Div(price))
+		filled = true
+		return false
+	})
+
+	if filled {
+		return result, nil
 	}
 
 	// Too shallow orderbook to fill order
@@ -44,7 +57,7 @@ func (ob *OrderBook) OrderBookAskConversion(amount decimal.Decimal) (decimal.Dec
 }
 
 // OrderBookBidConversion bid conversion
-func (ob *OrderBook) OrderBookBidConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+func (ob bookSides) OrderBookBidConversion(amount decimal.Decimal) (decimal.Decimal, error) {
 	if amount.IsZero() {
 		return decimal.Decimal{}, nil
 	}
@@ -56,24 +69,30 @@ func (ob *OrderBook) OrderBookBidConversion(amount decimal.Decimal) (decimal.Dec
 	var quantity decimal.Decimal
 	var exchangeableAmount decimal.Decimal
 
+	var result decimal.Decimal
+	filled := false
+
 	// Iterate over bids
-	bid, _ := ob.Bids.Front()
-	for bid != nil {
-		price = decimal.New(bid.Price, PriceDecimalExp)
-		quantity = decimal.New(bid.Size, SizeDecimalExp)
+	ob.Bids.Each(func(bid *ListNode) bool {
+		price = ob.Instrument.Price(bid.Price)
+		quantity = ob.Instrument.Size(bid.Size)
 
 		exchangeableAmount = quantity.Mul(price)
 		if quantity.LessThan(amountFrom) {
 			// Fill
 			amountFrom = amountFrom.Sub(quantity)
 			amountTo = amountTo.Add(exchangeableAmount)
-		} else {
-			// Last fill
-			return amountTo.Add(amountFrom.Mul(price)), nil
+			return true
 		}
 
-		// Get next
-		bid = bid.next
+		// Last fill
+		result = amountTo.Add(amountFrom.Mul(price))
+		filled = true
+		return false
+	})
+
+	if filled {
+		return result, nil
 	}
 
 	// Too shallow orderbook to fill order
@@ -81,7 +100,7 @@ func (ob *OrderBook) OrderBookBidConversion(amount decimal.Decimal) (decimal.Dec
 }
 
 // OrderBooAskReverseConversion ask reverse conversion
-func (ob *OrderBook) OrderBooAskReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+func (ob bookSides) OrderBooAskReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
 	if amount.IsZero() {
 		return decimal.Decimal{}, nil
 	}
@@ -93,31 +112,37 @@ func (ob *OrderBook) OrderBooAskReverseConversion(amount decimal.Decimal) (decim
 	var quantity decimal.Decimal
 	var exchangeableAmount decimal.Decimal
 
+	var result decimal.Decimal
+	filled := false
+
 	// Iterate over asks
-	ask, _ := ob.Asks.Front()
-	for ask != nil {
-		price = decimal.New(ask.Price, PriceDecimalExp)
-		quantity = decimal.New(ask.Size, SizeDecimalExp)
+	ob.Asks.Each(func(ask *ListNode) bool {
+		price = ob.Instrument.Price(ask.Price)
+		quantity = ob.Instrument.Size(ask.Size)
 
 		exchangeableAmount = quantity.Mul(price)
 		if quantity.LessThan(amountFrom) {
 			// Fill
 			amountFrom = amountFrom.Sub(quantity)
 			amountTo = amountTo.Add(exchangeableAmount)
-		} else {
-			// Last fill
-			return amountTo.Add(amountFrom.Mul(price)), nil
+			return true
 		}
 
-		// Get next
-		ask = ask.next
+		// Last fill
+		result = amountTo.Add(amountFrom.Mul(price))
+		filled = true
+		return false
+	})
+
+	if filled {
+		return result, nil
 	}
 
 	return decimal.Decimal{}, errors.New("too shallow Ask depth to fill order")
 }
 
 // OrderBookBidReverseConversion bid reverse conversion
-func (ob *OrderBook) OrderBookBidReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+func (ob bookSides) OrderBookBidReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
 	if amount.IsZero() {
 		return decimal.Decimal{}, nil
 	}
@@ -129,92 +154,108 @@ func (ob *OrderBook) OrderBookBidReverseConversion(amount decimal.Decimal) (deci
 	var quantity decimal.Decimal
 	var exchangeableAmount decimal.Decimal
 
+	var result decimal.Decimal
+	filled := false
+
 	// Iterate over bids
-	bid, _ := ob.Bids.Front()
-	for bid != nil {
-		price = decimal.New(bid.Price, PriceDecimalExp)
-		quantity = decimal.New(bid.Size, SizeDecimalExp)
+	ob.Bids.Each(func(bid *ListNode) bool {
+		price = ob.Instrument.Price(bid.Price)
+		quantity = ob.Instrument.Size(bid.Size)
 
 		exchangeableAmount = quantity.Mul(price)
 		if exchangeableAmount.LessThan(amountFrom) {
 			// Fill
 			amountFrom = amountFrom.Sub(exchangeableAmount)
 			amountTo = amountTo.Add(quantity)
-		} else {
-			// Last fill
-			return amountTo.Add(amountFrom.Div(price)), nil
+			return true
 		}
 
-		// Get next
-		bid = bid.next
+		// Last fill
+		result = amountTo.Add(amountFrom.Div(price))
+		filled = true
+		return false
+	})
+
+	if filled {
+		return result, nil
 	}
 
 	return decimal.Decimal{}, errors.New("too shallow Bid depth to fill order")
 }
 
 // GetBuyOrderBookDepthRequirement get orderbook depth fill requirement for buy order
-func (ob *OrderBook) GetBuyOrderBookDepthRequirement(maxPrice decimal.Decimal, amount decimal.Decimal) int {
+func (ob bookSides) GetBuyOrderBookDepthRequirement(maxPrice decimal.Decimal, amount decimal.Decimal) int {
 	i := 0
 
 	var price decimal.Decimal
 	var quantity decimal.Decimal
 	var exchanged decimal.Decimal
 
-	// Iterate over bids
-	order, _ := ob.Asks.Front()
-	for order != nil {
-		price = decimal.New(order.Price, PriceDecimalExp)
-		quantity = decimal.New(order.Size, SizeDecimalExp)
+	depth := -1
+
+	// Iterate over asks
+	ob.Asks.Each(func(order *ListNode) bool {
+		price = ob.Instrument.Price(order.Price)
+		quantity = ob.Instrument.Size(order.Size)
 
 		// Check max price for first entry
 		if i == 0 && price.GreaterThan(maxPrice) {
-			return 0
+			depth = 0
+			return false
 		}
 
 		exchanged = exchanged.Add(quantity)
 		if exchanged.GreaterThanOrEqual(quantity) {
-			return (i + 1)
+			depth = (i + 1)
+			return false
 		}
 
-		// Get next
-		order = order.next
-
 		// Increment count
 		i++
+		return true
+	})
+
+	if depth >= 0 {
+		return depth
 	}
 
 	return i
 }
 
 // GetSellOrderBookDepthRequirement get orderbook depth fill requirement for sell order
-func (ob *OrderBook) GetSellOrderBookDepthRequirement(minPrice decimal.Decimal, amount decimal.Decimal) int {
+func (ob bookSides) GetSellOrderBookDepthRequirement(minPrice decimal.Decimal, amount decimal.Decimal) int {
 	i := 0
 
 	var price decimal.Decimal
 	var quantity decimal.Decimal
 	var exchanged decimal.Decimal
 
+	depth := -1
+
 	// Iterate over bids
-	order, _ := ob.Bids.Front()
-	for order != nil {
-		price = decimal.New(order.Price, PriceDecimalExp)
-		quantity = decimal.New(order.Size, SizeDecimalExp)
+	ob.Bids.Each(func(order *ListNode) bool {
+		price = ob.Instrument.Price(order.Price)
+		quantity = ob.Instrument.Size(order.Size)
 
 		// Check min price for first entry
 		if i == 0 && price.LessThan(minPrice) {
-			return 0
+			depth = 0
+			return false
 		}
 
 		exchanged = exchanged.Add(quantity)
 		if exchanged.GreaterThanOrEqual(quantity) {
-			return (i + 1)
+			depth = (i + 1)
+			return false
 		}
 
-		// Get next
-		order = order.next
-
 		// Increment count
 		i++
+		return true
+	})
+
+	if depth >= 0 {
+		return depth
 	}
 
 	return i
@@ -223,7 +264,7 @@ func (ob *OrderBook) GetSellOrderBookDepthRequirement(minPrice decimal.Decimal,
 var two = decimal.NewFromInt(2)
 
 // GetMarketPrice return current market price
-func (ob *OrderBook) GetMarketPrice() (decimal.Decimal, error) {
+func (ob bookSides) GetMarketPrice() (decimal.Decimal, error) {
 	var price decimal.Decimal
 
 	if ob.Asks == nil || ob.Bids == nil {
@@ -242,8 +283,8 @@ func (ob *OrderBook) GetMarketPrice() (decimal.Decimal, error) {
 		return price, err
 	}
 
-	askPrice := decimal.New(ask.Price, PriceDecimalExp)
-	bidPrice := decimal.New(bid.Price, PriceDecimalExp)
+	askPrice := ob.Instrument.Price(ask.Price)
+	bidPrice := ob.Instrument.Price(bid.Price)
 
 	// Calculate market price
 	price = askPrice.Add(bidPrice).Div(two)
@@ -252,7 +293,7 @@ func (ob *OrderBook) GetMarketPrice() (decimal.Decimal, error) {
 }
 
 // GetFirstAskPrice returns first ask price
-func (ob *OrderBook) GetFirstAskPrice() (decimal.Decimal, error) {
+func (ob bookSides) GetFirstAskPrice() (decimal.Decimal, error) {
 	var price decimal.Decimal
 
 	// Get first ask
@@ -261,13 +302,13 @@ func (ob *OrderBook) GetFirstAskPrice() (decimal.Decimal, error) {
 		return price, err
 	}
 
-	price = decimal.New(ask.Price, PriceDecimalExp)
+	price = ob.Instrument.Price(ask.Price)
 
 	return price, nil
 }
 
 // GetFirstBidPrice returns first bid price
-func (ob *OrderBook) GetFirstBidPrice() (decimal.Decimal, error) {
+func (ob bookSides) GetFirstBidPrice() (decimal.Decimal, error) {
 	var price decimal.Decimal
 
 	// Get first bid
@@ -276,7 +317,61 @@ func (ob *OrderBook) GetFirstBidPrice() (decimal.Decimal, error) {
 		return price, err
 	}
 
-	price = decimal.New(bid.Price, PriceDecimalExp)
+	price = ob.Instrument.Price(bid.Price)
 
 	return price, nil
 }
+
+// sides returns book sides for conversion and price functions
+func (ob *OrderBook) sides() bookSides {
+	return bookSides{
+		Asks:       ob.Asks,
+		Bids:       ob.Bids,
+		Instrument: ob.Instrument,
+	}
+}
+
+// OrderBookAskConversion bid ask conversion
+func (ob *OrderBook) OrderBookAskConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	return ob.sides().OrderBookAskConversion(amount)
+}
+
+// OrderBookBidConversion bid conversion
+func (ob *OrderBook) OrderBookBidConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	return ob.sides().OrderBookBidConversion(amount)
+}
+
+// OrderBooAskReverseConversion ask reverse conversion
+func (ob *OrderBook) OrderBooAskReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	return ob.sides().OrderBooAskReverseConversion(amount)
+}
+
+// OrderBookBidReverseConversion bid reverse conversion
+func (ob *OrderBook) OrderBookBidReverseConversion(amount decimal.Decimal) (decimal.Decimal, error) {
+	return ob.sides().OrderBookBidReverseConversion(amount)
+}
+
+// GetBuyOrderBookDepthRequirement get orderbook depth fill requirement for buy order
+func (ob *OrderBook) GetBuyOrderBookDepthRequirement(maxPrice decimal.Decimal, amount decimal.Decimal) int {
+	return ob.sides().GetBuyOrderBookDepthRequirement(maxPrice, amount)
+}
+
+// GetSellOrderBookDepthRequirement get orderbook depth fill requirement for sell order
+func (ob *OrderBook) GetSellOrderBookDepthRequirement(minPrice decimal.Decimal, amount decimal.Decimal) int {
+	return ob.sides().GetSellOrderBookDepthRequirement(minPrice, amount)
+}
+
+// GetMarketPrice return current market price
+func (ob *OrderBook) GetMarketPrice() (decimal.Decimal, error) {
+	return ob.sides().GetMarketPrice()
+}
+
+// GetFirstAskPrice returns first ask price
+func (ob *OrderBook) GetFirstAskPrice() (decimal.Decimal, error) {
+	return ob.sides().GetFirstAskPrice()
+}
+
+// GetFirstBidPrice returns first bid price
+func (ob *OrderBook) GetFirstBidPrice() (decimal.Decimal, error) {
+	return ob.sides().GetFirstBidPrice()
+}
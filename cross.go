@@ -0,0 +1,133 @@
+package orderbook
+
+import (
+	"fmt"
+)
+
+// CrossPolicy defines how OrderBook handles crossed (best bid > best ask) or locked (best bid == best ask) book
+type CrossPolicy int
+
+const (
+	// CrossPolicyFlag keeps book as is, Crossed reports it and crossed callback is called
+	CrossPolicyFlag CrossPolicy = iota
+	// CrossPolicyError marks book not loaded and returns *ErrCrossedBook
+	CrossPolicyError
+	// CrossPolicyTrim removes stale levels crossed by levels of the applied event, flags book if still crossed
+	CrossPolicyTrim
+)
+
+// ErrCrossedBook is returned by CrossPolicyError when update leaves book crossed or locked
+type ErrCrossedBook struct {
+	Symbol   string
+	BidPrice int64
+	AskPrice int64
+}
+
+func (e *ErrCrossedBook) Error() string {
+	state := "crossed"
+	if e.BidPrice == e.AskPrice {
+		state = "locked"
+	}
+
+	return fmt.Sprintf("%s book(%s): bid %d >= ask %d", state, e.Symbol, e.BidPrice, e.AskPrice)
+}
+
+// WithCrossPolicy sets crossed and locked book policy, default CrossPolicyFlag
+func WithCrossPolicy(policy CrossPolicy) Option {
+	return func(ob *OrderBook) {
+		ob.crossPolicy = policy
+	}
+}
+
+// WithCrossedCallback sets fn called with best bid and ask prices when update leaves book crossed or locked
+func WithCrossedCallback(fn func(bidPrice, askPrice int64)) Option {
+	return func(ob *OrderBook) {
+		ob.onCrossed = fn
+	}
+}
+
+// Crossed reports whether book was crossed or locked after last update
+func (ob *OrderBook) Crossed() bool {
+	return ob.crossed
+}
+
+// bestPrices returns best bid and ask prices, crossed is set when bid >= ask
+func (ob *OrderBook) bestPrices() (bid, ask int64, crossed bool) {
+	bidNode, err := ob.Bids.Front()
+	if err != nil {
+		return 0, 0, false
+	}
+	bid = bidNode.Price
+
+	askNode, err := ob.Asks.Front()
+	if err != nil {
+		return bid, 0, false
+	}
+	ask = askNode.Price
+
+	return bid, ask, bid >= ask
+}
+
+// checkCrossed applies cross policy after update, event is nil for snapshots
+func (ob *OrderBook) checkCrossed(event *DepthEvent) error {
+	bid, ask, crossed := ob.bestPrices()
+
+	if crossed && event != nil && ob.crossPolicy == CrossPolicyTrim {
+		ob.trimCrossed(event)
+		bid, ask, crossed = ob.bestPrices()
+	}
+
+	ob.crossed = crossed
+	if !crossed {
+		return nil
+	}
+
+	if ob.onCrossed != nil {
+		ob.onCrossed(bid, ask)
+	}
+
+	if ob.crossPolicy == CrossPolicyError {
+		ob.Loaded = false
+
+		return &ErrCrossedBook{
+			Symbol:   ob.Symbol,
+			BidPrice: bid,
+			AskPrice: ask,
+		}
+	}
+
+	return nil
+}
+
+// trimCrossed removes asks at or below the highest bid set by event and bids at or above the lowest ask set by event
+func (ob *OrderBook) trimCrossed(event *DepthEvent) {
+	var freshBid, freshAsk int64
+
+	for _, bid := range event.Bids {
+		if !bid.Delete && bid.Quantity != 0 && bid.Price > freshBid {
+			freshBid = bid.Price
+		}
+	}
+
+	for _, ask := range event.Asks {
+		if !ask.Delete && ask.Quantity != 0 && (freshAsk == 0 || ask.Price < freshAsk) {
+			freshAsk = ask.Price
+		}
+	}
+
+	// Fresh bids remove stale asks
+	if freshBid > 0 {
+		for ask, err := ob.Asks.Front(); err == nil && ask.Price <= freshBid; ask, err = ob.Asks.Front() {
+			ob.Asks.Remove(ask.Price)
+			ob.record(OrderBookSideAsks, ListNode{Price: ask.Price}, true)
+		}
+	}
+
+	// Fresh asks remove stale bids
+	if freshAsk > 0 {
+		for bid, err := ob.Bids.Front(); err == nil && bid.Price >= freshAsk; bid, err = ob.Bids.Front() {
+			ob.Bids.Remove(bid.Price)
+			ob.record(OrderBookSideBids, ListNode{Price: bid.Price}, true)
+		}
+	}
+}
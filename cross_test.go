@@ -0,0 +1,88 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// crossingEvent bid update at 48002, crossing asks 48001 and 48002
+func crossingEvent() *DepthEvent {
+	return &DepthEvent{
+		Symbol:        "BTCUSDT",
+		FirstUpdateID: 101,
+		FinalUpdateID: 101,
+		Bids:          []*Bid{{Price: 4800200000000, Quantity: 1000000}},
+	}
+}
+
+// TestCrossPolicyFlag checks crossed book is flagged
+func TestCrossPolicyFlag(t *testing.T) {
+	var bidPrice, askPrice int64
+
+	ob := New("BTCUSDT", 10, WithCrossedCallback(func(bid, ask int64) {
+		bidPrice, askPrice = bid, ask
+	}))
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	if ob.Crossed() {
+		t.Errorf("Expected book not crossed")
+	}
+
+	if err := ob.ProcessEvent(crossingEvent()); err != nil {
+		t.Error(err)
+	}
+
+	if !ob.Crossed() || !ob.Loaded {
+		t.Errorf("Expected loaded crossed book")
+	}
+
+	if bidPrice != 4800200000000 || askPrice != 4800100000000 {
+		t.Errorf("Invalid callback prices! Expected: %d/%d, got: %d/%d", 4800200000000, 4800100000000, bidPrice, askPrice)
+	}
+}
+
+// TestCrossPolicyError checks crossed book returns error and is marked not loaded
+func TestCrossPolicyError(t *testing.T) {
+	ob := New("BTCUSDT", 10, WithCrossPolicy(CrossPolicyError))
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	// Locked book
+	event := crossingEvent()
+	event.Bids[0].Price = 4800100000000
+
+	err := ob.ProcessEvent(event)
+
+	var crossed *ErrCrossedBook
+	if !errors.As(err, &crossed) || ob.Loaded {
+		t.Errorf("Expected crossed book error, got: %v", err)
+		return
+	}
+
+	if crossed.BidPrice != crossed.AskPrice {
+		t.Errorf("Expected locked book, got: %s", err)
+	}
+}
+
+// TestCrossPolicyTrim checks stale asks are removed
+func TestCrossPolicyTrim(t *testing.T) {
+	ob := New("BTCUSDT", 10, WithCrossPolicy(CrossPolicyTrim))
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	if err := ob.ProcessEvent(crossingEvent()); err != nil {
+		t.Error(err)
+	}
+
+	if ob.Crossed() {
+		t.Errorf("Expected book not crossed")
+	}
+
+	ask, _ := ob.Asks.Front()
+	if ask.Price != 4800300000000 || ob.Asks.Size() != 3 {
+		t.Errorf("Invalid first ask! Expected: %d, got: %d", 4800300000000, ask.Price)
+	}
+
+	bid, _ := ob.Bids.Front()
+	if bid.Price != 4800200000000 {
+		t.Errorf("Invalid first bid! Expected: %d, got: %d", 4800200000000, bid.Price)
+	}
+}
@@ -0,0 +1,122 @@
+package orderbook
+
+import (
+	"fmt"
+)
+
+// LevelDecoder converts exchange price and size strings into instrument units, zero size marks level deleted
+type LevelDecoder struct {
+	Instrument Instrument
+	Rounding   RoundingMode
+}
+
+// DefaultLevelDecoder uses DefaultInstrument and rejects excess precision
+var DefaultLevelDecoder = LevelDecoder{
+	Instrument: DefaultInstrument,
+	Rounding:   RoundingStrict,
+}
+
+// Ask decodes ask level
+func (d LevelDecoder) Ask(price, size string) (*Ask, error) {
+	p, q, err := d.level(price, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ask{Price: p, Quantity: q, Delete: q == 0}, nil
+}
+
+// Bid decodes bid level
+func (d LevelDecoder) Bid(price, size string) (*Bid, error) {
+	p, q, err := d.level(price, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bid{Price: p, Quantity: q, Delete: q == 0}, nil
+}
+
+// Asks decodes [price, size] ask pairs
+func (d LevelDecoder) Asks(levels [][2]string) ([]*Ask, error) {
+	asks := make([]*Ask, 0, len(levels))
+	for _, level := range levels {
+		ask, err := d.Ask(level[0], level[1])
+		if err != nil {
+			return nil, err
+		}
+		asks = append(asks, ask)
+	}
+	return asks, nil
+}
+
+// Bids decodes [price, size] bid pairs
+func (d LevelDecoder) Bids(levels [][2]string) ([]*Bid, error) {
+	bids := make([]*Bid, 0, len(levels))
+	for _, level := range levels {
+		bid, err := d.Bid(level[0], level[1])
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, bid)
+	}
+	return bids, nil
+}
+
+// AskLevels decodes ask levels starting with price and size, following fields are ignored
+func (d LevelDecoder) AskLevels(levels [][]string) ([]*Ask, error) {
+	asks := make([]*Ask, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("invalid level %v", level)
+		}
+
+		ask, err := d.Ask(level[0], level[1])
+		if err != nil {
+			return nil, err
+		}
+		asks = append(asks, ask)
+	}
+	return asks, nil
+}
+
+// BidLevels decodes bid levels starting with price and size, following fields are ignored
+func (d LevelDecoder) BidLevels(levels [][]string) ([]*Bid, error) {
+	bids := make([]*Bid, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			return nil, fmt.Errorf("invalid level %v", level)
+		}
+
+		bid, err := d.Bid(level[0], level[1])
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, bid)
+	}
+	return bids, nil
+}
+
+// level parses price and size
+func (d LevelDecoder) level(price, size string) (int64, int64, error) {
+	p, err := d.Instrument.ParsePriceStrict(price, d.Rounding)
+	if err != nil {
+		return 0, 0, fmt.Errorf("level price: %w", err)
+	}
+
+	q, err := d.Instrument.ParseSizeStrict(size, d.Rounding)
+	if err != nil {
+		return 0, 0, fmt.Errorf("level size: %w", err)
+	}
+
+	return p, q, nil
+}
+
+// newBook creates book for adapter decoder instrument, venue options (e.g. checksum) follow caller options
+func (d LevelDecoder) newBook(symbol string, pruneThreshold int, options []Option, venue ...Option) *OrderBook {
+	all := make([]Option, 0, len(options)+len(venue)+1)
+	all = append(all, options...)
+	all = append(all, WithInstrument(d.Instrument))
+	all = append(all, venue...)
+
+	return New(symbol, pruneThreshold, all...)
+}
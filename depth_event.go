@@ -7,11 +7,17 @@ import (
 // DepthEvent define depth event
 type DepthEvent struct {
 	Symbol        string
-	FirstUpdateID int64  `json:"firstUpdateID"`
-	FinalUpdateID int64  `json:"finalUpdateId"`
-	Bids          []*Bid `json:"bids"`
-	Asks          []*Ask `json:"asks"`
-	Timestamp     time.Time
+	FirstUpdateID int64 `json:"firstUpdateID"`
+	FinalUpdateID int64 `json:"finalUpdateId"`
+	// PrevUpdateID final update ID of previous event, streams providing it (e.g. Binance futures pu) are
+	// checked for continuity against it instead of FirstUpdateID once bridged
+	PrevUpdateID int64  `json:"prevUpdateId"`
+	Bids         []*Bid `json:"bids"`
+	Asks         []*Ask `json:"asks"`
+	Timestamp    time.Time
+	// Checksum of book after event, verified when HasChecksum is set and OrderBook has checksum config
+	Checksum    uint32
+	HasChecksum bool
 }
 
 // Bid define bid info with price and quantity
@@ -19,6 +25,8 @@ type Bid struct {
 	Price    int64
 	Quantity int64
 	Delete   bool
+	// Count orders at level, 0 if unknown
+	Count int64
 }
 
 // Ask define ask info with price and quantity
@@ -26,4 +34,16 @@ type Ask struct {
 	Price    int64
 	Quantity int64
 	Delete   bool
+	// Count orders at level, 0 if unknown
+	Count int64
+}
+
+// level returns bid as level set by updateID at time at
+func (b *Bid) level(updateID int64, at time.Time) ListNode {
+	return ListNode{Price: b.Price, Size: b.Quantity, Count: b.Count, UpdateID: updateID, UpdatedAt: at}
+}
+
+// level returns ask as level set by updateID at time at
+func (a *Ask) level(updateID int64, at time.Time) ListNode {
+	return ListNode{Price: a.Price, Size: a.Quantity, Count: a.Count, UpdateID: updateID, UpdatedAt: at}
 }
@@ -2,7 +2,12 @@ package orderbook
 
 // DepthSnapshot struct
 type DepthSnapshot struct {
+	Symbol       string
 	LastUpdateID int64  `json:"lastUpdateId"`
 	Asks         []*Ask `json:"asks"`
 	Bids         []*Bid `json:"bids"`
+	// Checksum of snapshot levels, verified when HasChecksum is set, no buffered event was applied and
+	// OrderBook has checksum config
+	Checksum    uint32
+	HasChecksum bool
 }
@@ -0,0 +1,64 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrLevelNotFound is wrapped by Side.Remove errors when level does not exist
+var ErrLevelNotFound = errors.New("node not found")
+
+// ErrSequenceGap is returned when depth event does not continue book update IDs, book is marked not loaded
+type ErrSequenceGap struct {
+	Symbol string
+	// Expected first update ID (LastUpdateID + 1), or LastUpdateID when Previous is set
+	Expected int64
+	// Actual first update ID, or previous update ID when Previous is set
+	Actual int64
+	// Previous is set when gap was detected by event PrevUpdateID
+	Previous bool
+}
+
+func (e *ErrSequenceGap) Error() string {
+	id := "first"
+	if e.Previous {
+		id = "previous"
+	}
+
+	return fmt.Sprintf("sequence gap(%s): expected %s update ID %d, got %d", e.Symbol, id, e.Expected, e.Actual)
+}
+
+// LevelError describes invalid depth level
+type LevelError struct {
+	// Side OrderBookSideBids or OrderBookSideAsks
+	Side   int
+	Price  int64
+	Size   int64
+	Reason string
+}
+
+func (e LevelError) Error() string {
+	side := "ask"
+	if e.Side == OrderBookSideBids {
+		side = "bid"
+	}
+
+	return fmt.Sprintf("%s %d/%d: %s", side, e.Price, e.Size, e.Reason)
+}
+
+// ErrInvalidEvent is returned when depth event or snapshot contains invalid levels, book is left unchanged
+type ErrInvalidEvent struct {
+	Symbol        string
+	FinalUpdateID int64
+	Levels        []LevelError
+}
+
+func (e *ErrInvalidEvent) Error() string {
+	levels := make([]string, len(e.Levels))
+	for i, level := range e.Levels {
+		levels[i] = level.Error()
+	}
+
+	return fmt.Sprintf("invalid event(%s) %d: %s", e.Symbol, e.FinalUpdateID, strings.Join(levels, "; "))
+}
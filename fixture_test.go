@@ -0,0 +1,93 @@
+package orderbook
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"testing"
+)
+
+// readFixture reads testdata file
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// readFixtureLines reads testdata file lines
+func readFixtureLines(t *testing.T, name string) [][]byte {
+	t.Helper()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(readFixture(t, name)))
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines
+}
+
+// checkLevels compares side levels against price/size pairs
+func checkLevels(t *testing.T, name string, side SideReader, expected [][2]int64) {
+	t.Helper()
+
+	levels := sideLevels(side)
+	if len(levels) != len(expected) {
+		t.Errorf("%s: Invalid level count! Expected: %d, got: %d", name, len(expected), len(levels))
+		return
+	}
+
+	for i, level := range levels {
+		if level.Price != expected[i][0] || level.Size != expected[i][1] {
+			t.Errorf("%s: Invalid level %d! Expected: %d/%d, got: %d/%d", name, i, expected[i][0], expected[i][1], level.Price, level.Size)
+		}
+	}
+}
+
+// replay replayed fixture, errs holds book error of every applied snapshot and event in order
+type replay struct {
+	snapshots int
+	events    int
+	errs      []error
+}
+
+// checkNoErrors reports replayed message errors
+func checkNoErrors(t *testing.T, errs []error) {
+	t.Helper()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Invalid message %d! Expected no error, got: %v", i, err)
+		}
+	}
+}
+
+// replayFixture decodes lines and applies snapshots and events to book, decode errors are fatal and
+// messages decoded to neither (heartbeats, subscriptions, ...) are skipped
+func replayFixture(t *testing.T, ob *OrderBook, lines [][]byte, decode func(data []byte) (*DepthSnapshot, *DepthEvent, error)) replay {
+	t.Helper()
+
+	var r replay
+	for i, line := range lines {
+		snapshot, event, err := decode(line)
+		if err != nil {
+			t.Fatalf("Invalid line %d! Expected no error, got: %v", i, err)
+		}
+
+		switch {
+		case snapshot != nil:
+			r.snapshots++
+			r.errs = append(r.errs, ob.ProcessSnapshot(snapshot, nil))
+		case event != nil:
+			r.events++
+			r.errs = append(r.errs, ob.ProcessEvent(event))
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,75 @@
+package orderbook
+
+import (
+	"strconv"
+)
+
+// AppendDecimal appends value in units of 10^exp formatted as decimal string to dst
+func AppendDecimal(dst []byte, value int64, exp int32) []byte {
+	return appendDecimal(dst, value, int(-exp))
+}
+
+// AppendSatoshi appends int64 (satoshi) value formatted as decimal string to dst
+func AppendSatoshi(dst []byte, value int64) []byte {
+	return appendDecimal(dst, value, -PriceDecimalExp)
+}
+
+// AppendSize appends int64 (size) value formatted as decimal string to dst
+func AppendSize(dst []byte, value int64) []byte {
+	return appendDecimal(dst, value, -SizeDecimalExp)
+}
+
+// AppendPercentage appends int32 (percentage) value formatted as decimal string to dst
+func AppendPercentage(dst []byte, value int32) []byte {
+	return appendDecimal(dst, int64(value), -PercentageDecimalExp)
+}
+
+// AppendPrice appends price units formatted as decimal string to dst
+func (i Instrument) AppendPrice(dst []byte, value int64) []byte {
+	return appendDecimal(dst, value, int(-i.PriceExp))
+}
+
+// AppendSize appends size units formatted as decimal string to dst
+func (i Instrument) AppendSize(dst []byte, value int64) []byte {
+	return appendDecimal(dst, value, int(-i.SizeExp))
+}
+
+// intToDecimalString formats value shifted by decimals digits
+func intToDecimalString(value int64, decimals int) string {
+	var buf [32]byte
+	return string(appendDecimal(buf[:0], value, decimals))
+}
+
+// appendDecimal appends value shifted by decimals digits, at least one integer digit is written
+func appendDecimal(dst []byte, value int64, decimals int) []byte {
+	if decimals <= 0 {
+		return strconv.AppendInt(dst, value, 10)
+	}
+
+	magnitude := uint64(value)
+	if value < 0 {
+		dst = append(dst, '-')
+		magnitude = -magnitude
+	}
+
+	var buf [20]byte
+	digits := strconv.AppendUint(buf[:0], magnitude, 10)
+
+	// Integer part
+	integers := len(digits) - decimals
+	if integers <= 0 {
+		dst = append(dst, '0', '.')
+
+		// Fraction zeros
+		for ; integers < 0; integers++ {
+			dst = append(dst, '0')
+		}
+
+		return append(dst, digits...)
+	}
+
+	dst = append(dst, digits[:integers]...)
+	dst = append(dst, '.')
+
+	return append(dst, digits[integers:]...)
+}
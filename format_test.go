@@ -0,0 +1,61 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+// TestAppendDecimal checks append formatters match string formatters
+func TestAppendDecimal(t *testing.T) {
+	cases := map[int64]string{
+		0:                    "0.00000000",
+		1:                    "0.00000001",
+		12345678:             "0.12345678",
+		123456789:            "1.23456789",
+		-444800001200:        "-4448.00001200",
+		-9223372036854775808: "-92233720368.54775808",
+	}
+
+	for value, expected := range cases {
+		if result := string(AppendSatoshi([]byte("x"), value)); result != "x"+expected {
+			t.Errorf("Invalid satoshi string! Expected: %s, got: %s", "x"+expected, result)
+		}
+	}
+
+	if result := string(AppendSize(nil, 1500)); result != "0.001500" {
+		t.Errorf("Invalid size string! Expected: %s, got: %s", "0.001500", result)
+	}
+
+	if result := string(AppendPercentage(nil, -5)); result != "-0.0005" {
+		t.Errorf("Invalid percentage string! Expected: %s, got: %s", "-0.0005", result)
+	}
+
+	if result := string(AppendDecimal(nil, 15, 1)); result != "15" {
+		t.Errorf("Invalid decimal string! Expected: %s, got: %s", "15", result)
+	}
+
+	buf := make([]byte, 0, 32)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendSatoshi(buf[:0], 4800012345678)
+		buf = AppendSize(buf[:0], -1500)
+	})
+
+	if allocs != 0 {
+		t.Errorf("Invalid allocation count! Expected: %d, got: %f", 0, allocs)
+	}
+}
+
+func BenchmarkSatoshiToDecimalString(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SatoshiToDecimalString(4800012345678)
+	}
+}
+
+func BenchmarkAppendSatoshi(b *testing.B) {
+	buf := make([]byte, 0, 32)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendSatoshi(buf[:0], 4800012345678)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/matiss/orderbook
 
-go 1.16
+go 1.18
 
-require github.com/shopspring/decimal v1.2.0 // indirect
+require github.com/shopspring/decimal v1.2.0
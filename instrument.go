@@ -0,0 +1,111 @@
+package orderbook
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Instrument describes how integer prices and sizes of a symbol map to decimals
+type Instrument struct {
+	// PriceExp and SizeExp decimal exponents of price and size units, must not be positive
+	PriceExp int32
+	SizeExp  int32
+	// TickSize and LotSize in price and size units, levels off tick or lot are rejected. Zero allows any value.
+	TickSize int64
+	LotSize  int64
+}
+
+// DefaultInstrument satoshi prices and 10^6 sizes
+var DefaultInstrument = Instrument{
+	PriceExp: PriceDecimalExp,
+	SizeExp:  SizeDecimalExp,
+}
+
+// WithInstrument sets book instrument, default DefaultInstrument
+func WithInstrument(instrument Instrument) Option {
+	return func(ob *OrderBook) {
+		ob.Instrument = instrument
+	}
+}
+
+// Price converts price units to decimal
+func (i Instrument) Price(value int64) decimal.Decimal {
+	return decimal.New(value, i.PriceExp)
+}
+
+// Size converts size units to decimal
+func (i Instrument) Size(value int64) decimal.Decimal {
+	return decimal.New(value, i.SizeExp)
+}
+
+// PriceFromDecimal converts (shifts) decimal to price units, excess precision is truncated
+func (i Instrument) PriceFromDecimal(value decimal.Decimal) int64 {
+	return value.Shift(-i.PriceExp).IntPart()
+}
+
+// SizeFromDecimal converts (shifts) decimal to size units, excess precision is truncated
+func (i Instrument) SizeFromDecimal(value decimal.Decimal) int64 {
+	return value.Shift(-i.SizeExp).IntPart()
+}
+
+// ParsePrice parses decimal string to price units, 0 if invalid
+func (i Instrument) ParsePrice(value string) int64 {
+	return decimalStringToInt(value, int(-i.PriceExp))
+}
+
+// ParseSize parses decimal string to size units, 0 if invalid
+func (i Instrument) ParseSize(value string) int64 {
+	return decimalStringToInt(value, int(-i.SizeExp))
+}
+
+// FormatPrice converts price units to decimal string
+func (i Instrument) FormatPrice(value int64) string {
+	return intToDecimalString(value, int(-i.PriceExp))
+}
+
+// FormatSize converts size units to decimal string
+func (i Instrument) FormatSize(value int64) string {
+	return intToDecimalString(value, int(-i.SizeExp))
+}
+
+// levelReason returns why level does not fit tick and lot size, empty if it does
+func (i Instrument) levelReason(price, size int64) string {
+	if i.TickSize > 0 && price%i.TickSize != 0 {
+		return "price is not multiple of tick size"
+	}
+
+	if i.LotSize > 0 && size%i.LotSize != 0 {
+		return "size is not multiple of lot size"
+	}
+
+	return ""
+}
+
+// decimalStringToInt parses integer or decimal string shifted by decimals digits, excess digits are truncated
+func decimalStringToInt(value string, decimals int) int64 {
+	integer, fraction := value, ""
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		integer, fraction = value[:dot], value[dot+1:]
+	}
+
+	if len(fraction) > decimals {
+		fraction = fraction[:decimals]
+	}
+
+	var b strings.Builder
+	b.Grow(len(integer) + decimals)
+	b.WriteString(integer)
+	b.WriteString(fraction)
+	for n := len(fraction); n < decimals; n++ {
+		b.WriteByte('0')
+	}
+
+	significand, err := strconv.ParseInt(b.String(), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return significand
+}
@@ -0,0 +1,90 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestInstrumentFormat checks parsing and formatting with custom exponents
+func TestInstrumentFormat(t *testing.T) {
+	shib := Instrument{PriceExp: -10, SizeExp: 0}
+
+	if price := shib.ParsePrice("0.0000089123"); price != 89123 {
+		t.Errorf("Invalid price! Expected: %d, got: %d", 89123, price)
+	}
+
+	if price := shib.FormatPrice(89123); price != "0.0000089123" {
+		t.Errorf("Invalid price string! Expected: %s, got: %s", "0.0000089123", price)
+	}
+
+	if size := shib.ParseSize("1500000"); size != 1500000 {
+		t.Errorf("Invalid size! Expected: %d, got: %d", 1500000, size)
+	}
+
+	if size := shib.FormatSize(1500000); size != "1500000" {
+		t.Errorf("Invalid size string! Expected: %s, got: %s", "1500000", size)
+	}
+
+	futures := Instrument{PriceExp: -1, SizeExp: -3}
+
+	cases := map[string]int64{
+		"0.001":   1,
+		"12.5":    12500,
+		"-3.0009": -3000,
+		"abc":     0,
+	}
+
+	for value, expected := range cases {
+		if size := futures.ParseSize(value); size != expected {
+			t.Errorf("Invalid size for %s! Expected: %d, got: %d", value, expected, size)
+		}
+	}
+
+	if size := futures.FormatSize(-3000); size != "-3.000" {
+		t.Errorf("Invalid size string! Expected: %s, got: %s", "-3.000", size)
+	}
+}
+
+// TestInstrumentBook checks conversions, checksum and tick/lot validation use book instrument
+func TestInstrumentBook(t *testing.T) {
+	ob := New("BTCUSDT-PERP", 10, WithInstrument(Instrument{PriceExp: -1, SizeExp: -3, TickSize: 5, LotSize: 1}))
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 480010, Quantity: 1500}},
+		Bids:         []*Bid{{Price: 479995, Quantity: 2000}},
+	}, nil)
+
+	price, _ := ob.GetMarketPrice()
+	if !price.Equal(decimal.RequireFromString("48000.25")) {
+		t.Errorf("Invalid market price! Expected: %s, got: %s", "48000.25", price)
+	}
+
+	if sides := ob.sides(); sides.Instrument.SizeExp != -3 {
+		t.Errorf("Invalid size exponent! Expected: %d, got: %d", -3, sides.Instrument.SizeExp)
+	}
+
+	err := ob.ProcessEvent(&DepthEvent{
+		FinalUpdateID: 2,
+		Asks:          []*Ask{{Price: 480012, Quantity: 1000}},
+	})
+
+	var invalid *ErrInvalidEvent
+	if !errors.As(err, &invalid) || invalid.Levels[0].Reason != "price is not multiple of tick size" {
+		t.Errorf("Expected tick size error, got: %v", err)
+	}
+
+	// 48001 1.5, 47999.5 2
+	expected := ChecksumConfig{Scheme: ChecksumOKX}
+	ob2 := New("BTCUSDT-PERP", 10)
+	ob2.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 4800100000000, Quantity: 1500000}},
+		Bids:         []*Bid{{Price: 4799950000000, Quantity: 2000000}},
+	}, nil)
+
+	if ob.Checksum(expected) != ob2.Checksum(expected) {
+		t.Errorf("Invalid checksum! Expected: %d, got: %d", ob2.Checksum(expected), ob.Checksum(expected))
+	}
+}
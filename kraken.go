@@ -0,0 +1,155 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// krakenMessage v2 channel message
+type krakenMessage struct {
+	Channel string       `json:"channel"`
+	Type    string       `json:"type"`
+	Data    []krakenBook `json:"data"`
+}
+
+// krakenBook v2 book channel snapshot or update
+type krakenBook struct {
+	Symbol    string        `json:"symbol"`
+	Bids      []krakenLevel `json:"bids"`
+	Asks      []krakenLevel `json:"asks"`
+	Checksum  uint32        `json:"checksum"`
+	Timestamp string        `json:"timestamp"`
+}
+
+// krakenLevel price and quantity, json.Number keeps number text as sent
+type krakenLevel struct {
+	Price json.Number `json:"price"`
+	Qty   json.Number `json:"qty"`
+}
+
+// KrakenAdapter converts Kraken WebSocket v2 book channel messages. Kraken sends no update IDs, adapter
+// assigns synthetic per symbol IDs increasing by one per message. Kraken truncates book to subscribed
+// depth after every update and republishes levels coming back into view, books created by NewBook prune
+// to Depth so levels falling out of view are removed. Symbol ID counters are updated by Decode without
+// locking, messages of one connection must be decoded in order from one goroutine. Book message failing
+// to decode still takes its ID, following update reports sequence gap.
+type KrakenAdapter struct {
+	Decoder LevelDecoder
+	// Depth subscribed book depth
+	Depth int
+
+	ids map[string]int64
+}
+
+// NewKrakenAdapter creates new struct instance of *KrakenAdapter
+func NewKrakenAdapter(decoder LevelDecoder, depth int) *KrakenAdapter {
+	return &KrakenAdapter{
+		Decoder: decoder,
+		Depth:   depth,
+		ids:     make(map[string]int64),
+	}
+}
+
+// NewBook creates book pruned to subscribed depth verifying Kraken checksums, priceDecimals and
+// qtyDecimals are pair price_precision and qty_precision
+func (a *KrakenAdapter) NewBook(symbol string, priceDecimals, qtyDecimals int, options ...Option) *OrderBook {
+	return a.Decoder.newBook(symbol, a.Depth, options, WithChecksum(ChecksumConfig{
+		Scheme:        ChecksumKraken,
+		PriceDecimals: priceDecimals,
+		SizeDecimals:  qtyDecimals,
+	}))
+}
+
+// Decode converts book snapshot message to DepthSnapshot and update message to DepthEvent, both carrying
+// Kraken checksum. Other channels (heartbeat, status, subscribe responses) return nil snapshot and event.
+func (a *KrakenAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	var msg krakenMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("kraken book: %w", err)
+	}
+
+	if msg.Channel != "book" || (msg.Type != "snapshot" && msg.Type != "update") {
+		return nil, nil, nil
+	}
+
+	// Book messages carry single symbol
+	if len(msg.Data) != 1 {
+		return nil, nil, fmt.Errorf("kraken book: expected 1 book, got %d", len(msg.Data))
+	}
+	book := &msg.Data[0]
+	id := a.nextID(book.Symbol)
+
+	asks, err := a.asks(book.Asks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kraken book(%s): %w", book.Symbol, err)
+	}
+
+	bids, err := a.bids(book.Bids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("kraken book(%s): %w", book.Symbol, err)
+	}
+
+	if msg.Type == "snapshot" {
+		return &DepthSnapshot{
+			Symbol:       book.Symbol,
+			LastUpdateID: id,
+			Asks:         asks,
+			Bids:         bids,
+			Checksum:     book.Checksum,
+			HasChecksum:  true,
+		}, nil, nil
+	}
+
+	event := &DepthEvent{
+		Symbol:        book.Symbol,
+		FirstUpdateID: id,
+		FinalUpdateID: id,
+		Asks:          asks,
+		Bids:          bids,
+		Checksum:      book.Checksum,
+		HasChecksum:   true,
+	}
+
+	if book.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339Nano, book.Timestamp)
+		if err != nil {
+			return nil, nil, fmt.Errorf("kraken book(%s): %w", book.Symbol, err)
+		}
+		event.Timestamp = timestamp
+	}
+
+	return nil, event, nil
+}
+
+// nextID returns next synthetic symbol update ID
+func (a *KrakenAdapter) nextID(symbol string) int64 {
+	a.ids[symbol]++
+	return a.ids[symbol]
+}
+
+// asks decodes ask levels
+func (a *KrakenAdapter) asks(levels []krakenLevel) ([]*Ask, error) {
+	asks := make([]*Ask, 0, len(levels))
+	for _, level := range levels {
+		ask, err := a.Decoder.Ask(level.Price.String(), level.Qty.String())
+		if err != nil {
+			return nil, err
+		}
+		asks = append(asks, ask)
+	}
+	return asks, nil
+}
+
+// bids decodes bid levels
+func (a *KrakenAdapter) bids(levels []krakenLevel) ([]*Bid, error) {
+	bids := make([]*Bid, 0, len(levels))
+	for _, level := range levels {
+		bid, err := a.Decoder.Bid(level.Price.String(), level.Qty.String())
+		if err != nil {
+			return nil, err
+		}
+		bids = append(bids, bid)
+	}
+	return bids, nil
+}
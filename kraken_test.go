@@ -0,0 +1,63 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestKrakenAdapter applies hand-written book messages with truncation and checksum verification
+func TestKrakenAdapter(t *testing.T) {
+	// Kraken quantities carry 8 decimals
+	adapter := NewKrakenAdapter(LevelDecoder{Instrument: Instrument{PriceExp: -8, SizeExp: -8}}, 10)
+	ob := adapter.NewBook("BTC/USD", 1, 8)
+
+	lines := readFixtureLines(t, "kraken/book.jsonl")
+	checkNoErrors(t, replayFixture(t, ob, lines, adapter.Decode).errs)
+
+	if !ob.Loaded || ob.LastUpdateID != 3 || ob.Asks.Size() != 10 || ob.Bids.Size() != 10 {
+		t.Errorf("Invalid book! Loaded: %t, update ID: %d, levels: %d/%d", ob.Loaded, ob.LastUpdateID, ob.Asks.Size(), ob.Bids.Size())
+	}
+
+	// Truncated bid is gone, republished ask is back
+	bids := sideLevels(ob.Bids)
+	if bid := bids[len(bids)-1]; bid.Price != 2749550000000 {
+		t.Errorf("Invalid last bid! Expected: %d, got: %d", 2749550000000, bid.Price)
+	}
+
+	asks := sideLevels(ob.Asks)
+	if ask := asks[len(asks)-1]; ask.Price != 2750550000000 || ask.Size != 75000000 {
+		t.Errorf("Invalid last ask! Expected: %d/%d, got: %d/%d", 2750550000000, 75000000, ask.Price, ask.Size)
+	}
+
+	// Corrupted checksum
+	_, event, _ := adapter.Decode([]byte(`{"channel":"book","type":"update","data":[{"symbol":"BTC/USD","bids":[{"price":27499.7,"qty":1.4}],"asks":[],"checksum":1}]}`))
+
+	var mismatch *ErrChecksumMismatch
+	if err := ob.ProcessEvent(event); !errors.As(err, &mismatch) || ob.Loaded {
+		t.Errorf("Expected checksum mismatch, got: %v", err)
+	}
+
+	// Corrupted snapshot checksum
+	snapshot, _, _ := adapter.Decode(lines[1])
+	snapshot.Checksum++
+	if err := ob.ProcessSnapshot(snapshot, nil); !errors.As(err, &mismatch) {
+		t.Errorf("Expected snapshot checksum mismatch, got: %v", err)
+	}
+
+	// Failed update takes its ID
+	snapshot, _, _ = adapter.Decode(lines[1])
+	if err := ob.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := adapter.Decode([]byte(`{"channel":"book","type":"update","data":[{"symbol":"BTC/USD","bids":[{"price":27499.123456789,"qty":1}],"asks":[],"checksum":1}]}`)); err == nil {
+		t.Errorf("Expected excess precision error")
+	}
+
+	_, event, _ = adapter.Decode([]byte(`{"channel":"book","type":"update","data":[{"symbol":"BTC/USD","bids":[],"asks":[],"checksum":1}]}`))
+
+	var gap *ErrSequenceGap
+	if err := ob.ProcessEvent(event); !errors.As(err, &gap) {
+		t.Errorf("Expected sequence gap, got: %v", err)
+	}
+}
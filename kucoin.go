@@ -0,0 +1,148 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// kucoinMessage level2 topic message
+type kucoinMessage struct {
+	Type    string `json:"type"`
+	Topic   string `json:"topic"`
+	Subject string `json:"subject"`
+	Data    struct {
+		Changes struct {
+			Asks [][3]string `json:"asks"`
+			Bids [][3]string `json:"bids"`
+		} `json:"changes"`
+		SequenceStart int64  `json:"sequenceStart"`
+		SequenceEnd   int64  `json:"sequenceEnd"`
+		Symbol        string `json:"symbol"`
+		Time          int64  `json:"time"`
+	} `json:"data"`
+}
+
+// kucoinSnapshot /api/v3/market/orderbook/level2 response
+type kucoinSnapshot struct {
+	Code string `json:"code"`
+	Data struct {
+		Sequence int64       `json:"sequence,string"`
+		Time     int64       `json:"time"`
+		Bids     [][2]string `json:"bids"`
+		Asks     [][2]string `json:"asks"`
+	} `json:"data"`
+}
+
+// DecodeKucoinDepthEvent decodes KuCoin /market/level2 trade.l2update message, other messages (welcome, ack,
+// pong) return nil event. Sizes are absolute, so bridging message may be applied as a whole over snapshot:
+// changes for the same price are collapsed to the latest sequence and sequence only placeholders (price 0)
+// are dropped.
+func DecodeKucoinDepthEvent(d LevelDecoder, data []byte) (*DepthEvent, error) {
+	var msg kucoinMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("kucoin level2: %w", err)
+	}
+
+	if msg.Type != "message" || msg.Subject != "trade.l2update" {
+		return nil, nil
+	}
+
+	book := &msg.Data
+
+	asks, err := kucoinChanges(book.Changes.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("kucoin level2(%s) %d: %w", book.Symbol, book.SequenceEnd, err)
+	}
+
+	bids, err := kucoinChanges(book.Changes.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("kucoin level2(%s) %d: %w", book.Symbol, book.SequenceEnd, err)
+	}
+
+	event := &DepthEvent{
+		Symbol:        book.Symbol,
+		FirstUpdateID: book.SequenceStart,
+		FinalUpdateID: book.SequenceEnd,
+		Timestamp:     time.UnixMilli(book.Time),
+	}
+
+	for _, change := range asks {
+		ask, err := d.Ask(change[0], change[1])
+		if err != nil {
+			return nil, fmt.Errorf("kucoin level2(%s) %d: %w", book.Symbol, book.SequenceEnd, err)
+		}
+		event.Asks = append(event.Asks, ask)
+	}
+
+	for _, change := range bids {
+		bid, err := d.Bid(change[0], change[1])
+		if err != nil {
+			return nil, fmt.Errorf("kucoin level2(%s) %d: %w", book.Symbol, book.SequenceEnd, err)
+		}
+		event.Bids = append(event.Bids, bid)
+	}
+
+	return event, nil
+}
+
+// kucoinChanges drops placeholders and keeps latest [price, size, sequence] change per price
+func kucoinChanges(changes [][3]string) ([][3]string, error) {
+	// Index of latest change per price
+	latest := make(map[string]int, len(changes))
+	sequences := make([]int64, len(changes))
+
+	for i, change := range changes {
+		if change[0] == "0" {
+			continue
+		}
+
+		sequence, err := ParseDecimal(change[2], 0, RoundingStrict)
+		if err != nil {
+			return nil, fmt.Errorf("change sequence: %w", err)
+		}
+		sequences[i] = sequence
+
+		if j, ok := latest[change[0]]; !ok || sequence > sequences[j] {
+			latest[change[0]] = i
+		}
+	}
+
+	kept := make([][3]string, 0, len(latest))
+	for i, change := range changes {
+		if j, ok := latest[change[0]]; ok && i == j {
+			kept = append(kept, change)
+		}
+	}
+
+	return kept, nil
+}
+
+// DecodeKucoinDepthSnapshot decodes KuCoin /api/v3/market/orderbook/level2 (or level2_100) response
+func DecodeKucoinDepthSnapshot(d LevelDecoder, symbol string, data []byte) (*DepthSnapshot, error) {
+	var msg kucoinSnapshot
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("kucoin level2 snapshot: %w", err)
+	}
+
+	if msg.Code != "200000" {
+		return nil, fmt.Errorf("kucoin level2 snapshot(%s): error code %s", symbol, msg.Code)
+	}
+
+	asks, err := d.Asks(msg.Data.Asks)
+	if err != nil {
+		return nil, fmt.Errorf("kucoin level2 snapshot(%s) %d: %w", symbol, msg.Data.Sequence, err)
+	}
+
+	bids, err := d.Bids(msg.Data.Bids)
+	if err != nil {
+		return nil, fmt.Errorf("kucoin level2 snapshot(%s) %d: %w", symbol, msg.Data.Sequence, err)
+	}
+
+	return &DepthSnapshot{
+		Symbol:       symbol,
+		LastUpdateID: msg.Data.Sequence,
+		Asks:         asks,
+		Bids:         bids,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestKucoinAdapter syncs hand-written level2 messages over REST snapshot, last message skips sequences
+func TestKucoinAdapter(t *testing.T) {
+	snapshot, err := DecodeKucoinDepthSnapshot(DefaultLevelDecoder, "BTC-USDT", readFixture(t, "kucoin/level2_snapshot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ob := New("BTC-USDT", 100)
+	sync := NewSynchronizer(ob, &fakeFetcher{snapshots: []*DepthSnapshot{snapshot}})
+
+	var errs []error
+	for _, line := range readFixtureLines(t, "kucoin/level2.jsonl") {
+		event, err := DecodeKucoinDepthEvent(DefaultLevelDecoder, line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event != nil {
+			errs = append(errs, sync.HandleEvent(event))
+		}
+	}
+
+	for i, err := range errs[:3] {
+		if err != nil {
+			t.Errorf("Invalid message %d! Expected no error, got: %v", i, err)
+		}
+	}
+
+	if ob.LastUpdateID != 14103850 || sync.State() != SyncStateResyncing {
+		t.Errorf("Invalid book! Expected: %d resyncing, got: %d %s", 14103850, ob.LastUpdateID, sync.State())
+	}
+
+	// Bridging message applied with latest change per price
+	checkLevels(t, "asks", ob.Asks, [][2]int64{{1890750000000, 500000}})
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{1889250000000, 700000}, {1889190000000, 250000}, {1889000000000, 1200000}})
+
+	// Error responses
+	if _, err := DecodeKucoinDepthSnapshot(DefaultLevelDecoder, "BTC-USDT", []byte(`{"code":"400100","msg":"invalid symbol"}`)); err == nil {
+		t.Errorf("Expected error code")
+	}
+
+	_, err = DecodeKucoinDepthEvent(DefaultLevelDecoder, []byte(`{"type":"message","subject":"trade.l2update","data":{"changes":{"asks":[["1","1","x"]],"bids":[]}}}`))
+	if !errors.Is(err, ErrInvalidNumber) {
+		t.Errorf("Expected invalid sequence, got: %v", err)
+	}
+}
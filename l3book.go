@@ -0,0 +1,444 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrDuplicateOrder is returned when added order ID is already on book
+var ErrDuplicateOrder = errors.New("duplicate order ID")
+
+// OrderEventType order book change type
+type OrderEventType int
+
+const (
+	// OrderEventAdd adds order to the back of its price queue
+	OrderEventAdd OrderEventType = iota
+	// OrderEventModify changes order size or price. Size decrease at same price keeps queue position,
+	// size increase or price change moves order to the back of its price queue.
+	OrderEventModify
+	// OrderEventCancel removes order
+	OrderEventCancel
+	// OrderEventMatch reduces order size by matched size, fully matched order is removed
+	OrderEventMatch
+)
+
+// Order is resting order, orders returned by L3Book must not be modified
+type Order struct {
+	ID string
+	// Side OrderBookSideBids or OrderBookSideAsks
+	Side  int
+	Price int64
+	Size  int64
+	// Time order was added
+	Time time.Time
+
+	prev *Order
+	next *Order
+}
+
+// Next order in price queue, nil for last order
+func (o *Order) Next() *Order {
+	return o.next
+}
+
+// OrderEvent is order book change, Side and Price are used by add and modify (Price only), Size is
+// added, new or matched size. Events for orders not on book (e.g. cancels of never rested orders)
+// only advance update ID.
+type OrderEvent struct {
+	Type      OrderEventType
+	UpdateID  int64
+	OrderID   string
+	Side      int
+	Price     int64
+	Size      int64
+	Timestamp time.Time
+}
+
+// OrderSnapshot define order book snapshot, orders of each side in queue priority order, order Side is ignored
+type OrderSnapshot struct {
+	Symbol       string
+	LastUpdateID int64
+	Asks         []*Order
+	Bids         []*Order
+}
+
+// orderQueue FIFO queue of orders at price
+type orderQueue struct {
+	head  *Order
+	tail  *Order
+	size  int64
+	count int64
+}
+
+// push appends order
+func (q *orderQueue) push(order *Order) {
+	order.prev = q.tail
+	order.next = nil
+	if q.tail == nil {
+		q.head = order
+	} else {
+		q.tail.next = order
+	}
+	q.tail = order
+
+	q.size += order.Size
+	q.count++
+}
+
+// remove unlinks order
+func (q *orderQueue) remove(order *Order) {
+	if order.prev == nil {
+		q.head = order.next
+	} else {
+		order.prev.next = order.next
+	}
+
+	if order.next == nil {
+		q.tail = order.prev
+	} else {
+		order.next.prev = order.prev
+	}
+	order.prev = nil
+	order.next = nil
+
+	q.size -= order.Size
+	q.count--
+}
+
+// L3Book is order by order book keeping FIFO queue per price. Aggregated levels (size and order count)
+// are derived from queues and applied to Book through ProcessEvent, so price and conversion functions,
+// views, subscriptions and BBO listeners work as for level books. Book must not be updated directly and
+// does not prune levels. L3Book is not safe for concurrent use, callers serialize events and queue reads.
+type L3Book struct {
+	Book *OrderBook
+	// Contiguous requires update IDs to increase by one (e.g. Coinbase full channel sequence),
+	// otherwise they only have to increase
+	Contiguous bool
+
+	orders map[string]*Order
+	queues [2]map[int64]*orderQueue
+}
+
+// NewL3Book creates new struct instance of *L3Book, options configure Book
+func NewL3Book(symbol string, options ...Option) *L3Book {
+	b := &L3Book{
+		Book: New(symbol, 0, options...),
+	}
+	b.reset()
+
+	return b
+}
+
+// reset drops all orders
+func (b *L3Book) reset() {
+	b.orders = make(map[string]*Order)
+	b.queues = [2]map[int64]*orderQueue{make(map[int64]*orderQueue), make(map[int64]*orderQueue)}
+}
+
+// Order returns order by ID
+func (b *L3Book) Order(id string) (*Order, bool) {
+	order, ok := b.orders[id]
+	return order, ok
+}
+
+// Len returns order count
+func (b *L3Book) Len() int {
+	return len(b.orders)
+}
+
+// Queue returns first order at price, nil if there is none
+func (b *L3Book) Queue(side int, price int64) *Order {
+	if side != OrderBookSideBids && side != OrderBookSideAsks {
+		return nil
+	}
+
+	if q, ok := b.queues[side][price]; ok {
+		return q.head
+	}
+	return nil
+}
+
+// EachOrder calls fn for every order at price in queue order until fn returns false
+func (b *L3Book) EachOrder(side int, price int64, fn func(order *Order) bool) {
+	for order := b.Queue(side, price); order != nil; order = order.next {
+		if !fn(order) {
+			return
+		}
+	}
+}
+
+// QueuePosition returns number and total size of orders ahead of order in its price queue
+func (b *L3Book) QueuePosition(id string) (ahead int, sizeAhead int64, ok bool) {
+	order, ok := b.orders[id]
+	if !ok {
+		return 0, 0, false
+	}
+
+	for o := order.prev; o != nil; o = o.prev {
+		ahead++
+		sizeAhead += o.Size
+	}
+
+	return ahead, sizeAhead, true
+}
+
+// ProcessSnapshot replaces book with order snapshot, snapshot orders are copied. Buffered events not newer
+// than snapshot are skipped. On invalid snapshot book is left as is.
+func (b *L3Book) ProcessSnapshot(snapshot *OrderSnapshot, eventBuffer []*OrderEvent) error {
+	orders := make(map[string]*Order, len(snapshot.Asks)+len(snapshot.Bids))
+	queues := [2]map[int64]*orderQueue{make(map[int64]*orderQueue), make(map[int64]*orderQueue)}
+
+	depth := &DepthSnapshot{
+		Symbol:       snapshot.Symbol,
+		LastUpdateID: snapshot.LastUpdateID,
+	}
+
+	sides := [2][]*Order{OrderBookSideBids: snapshot.Bids, OrderBookSideAsks: snapshot.Asks}
+	for side, sideOrders := range sides {
+		for _, o := range sideOrders {
+			if _, ok := orders[o.ID]; ok {
+				return fmt.Errorf("snapshot(%s) order %s: %w", b.Book.Symbol, o.ID, ErrDuplicateOrder)
+			}
+
+			if o.Size <= 0 {
+				return fmt.Errorf("snapshot(%s) order %s: size must be positive", b.Book.Symbol, o.ID)
+			}
+
+			order := &Order{ID: o.ID, Side: side, Price: o.Price, Size: o.Size, Time: o.Time}
+			orders[order.ID] = order
+
+			q, ok := queues[side][order.Price]
+			if !ok {
+				q = &orderQueue{}
+				queues[side][order.Price] = q
+			}
+			q.push(order)
+		}
+	}
+
+	for side, sideQueues := range queues {
+		for price, q := range sideQueues {
+			if side == OrderBookSideAsks {
+				depth.Asks = append(depth.Asks, &Ask{Price: price, Quantity: q.size, Count: q.count})
+			} else {
+				depth.Bids = append(depth.Bids, &Bid{Price: price, Quantity: q.size, Count: q.count})
+			}
+		}
+	}
+
+	// Sides are swapped once snapshot is applied, later errors (checksum, crossed book) keep it
+	swapped, err := b.Book.loadSnapshot(depth, nil)
+	if !swapped {
+		return err
+	}
+
+	b.orders = orders
+	b.queues = queues
+	if err != nil {
+		return err
+	}
+
+	for _, event := range eventBuffer {
+		if event.UpdateID <= b.Book.LastUpdateID {
+			continue
+		}
+
+		if err := b.ProcessEvent(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessEvent applies order event. Event is applied all-or-nothing, errors of Book.ProcessEvent
+// (sequence gap, invalid levels, ...) are returned and orders are left unchanged unless Book applied event.
+func (b *L3Book) ProcessEvent(event *OrderEvent) error {
+	depth := &DepthEvent{
+		Symbol:        b.Book.Symbol,
+		FinalUpdateID: event.UpdateID,
+		Timestamp:     event.Timestamp,
+	}
+	if b.Contiguous {
+		depth.FirstUpdateID = event.UpdateID
+	}
+
+	apply, err := b.prepare(event, depth)
+	if err != nil {
+		return err
+	}
+
+	// Orders are changed only when book applied event, replayed or rejected events leave book as is
+	lastUpdateID := b.Book.LastUpdateID
+	err = b.Book.ProcessEvent(depth)
+	if b.Book.LastUpdateID == lastUpdateID || b.Book.LastUpdateID != event.UpdateID {
+		return err
+	}
+
+	if apply != nil {
+		apply()
+	}
+
+	return err
+}
+
+// prepare adds levels changed by event to depth event and returns function applying event to queues
+func (b *L3Book) prepare(event *OrderEvent, depth *DepthEvent) (func(), error) {
+	if event.Type == OrderEventAdd {
+		return b.prepareAdd(event, depth)
+	}
+
+	order, ok := b.orders[event.OrderID]
+	if !ok {
+		return nil, nil
+	}
+
+	switch event.Type {
+	case OrderEventCancel:
+		b.setLevel(depth, order.Side, order.Price, -order.Size, -1)
+
+		return func() {
+			b.remove(order)
+		}, nil
+	case OrderEventMatch:
+		if event.Size <= 0 || event.Size > order.Size {
+			return nil, fmt.Errorf("order event(%s) %d: invalid match size %d for order %s of size %d", b.Book.Symbol, event.UpdateID, event.Size, order.ID, order.Size)
+		}
+
+		if event.Size == order.Size {
+			b.setLevel(depth, order.Side, order.Price, -order.Size, -1)
+
+			return func() {
+				b.remove(order)
+			}, nil
+		}
+
+		b.setLevel(depth, order.Side, order.Price, -event.Size, 0)
+
+		return func() {
+			b.queues[order.Side][order.Price].size -= event.Size
+			order.Size -= event.Size
+		}, nil
+	case OrderEventModify:
+		return b.prepareModify(event, order, depth)
+	}
+
+	return nil, fmt.Errorf("order event(%s) %d: invalid type %d", b.Book.Symbol, event.UpdateID, event.Type)
+}
+
+// prepareAdd prepares adding order
+func (b *L3Book) prepareAdd(event *OrderEvent, depth *DepthEvent) (func(), error) {
+	if _, ok := b.orders[event.OrderID]; ok {
+		return nil, fmt.Errorf("order event(%s) %d order %s: %w", b.Book.Symbol, event.UpdateID, event.OrderID, ErrDuplicateOrder)
+	}
+
+	if event.Side != OrderBookSideBids && event.Side != OrderBookSideAsks {
+		return nil, fmt.Errorf("order event(%s) %d: invalid side %d", b.Book.Symbol, event.UpdateID, event.Side)
+	}
+
+	if event.Size <= 0 {
+		return nil, fmt.Errorf("order event(%s) %d: size must be positive", b.Book.Symbol, event.UpdateID)
+	}
+
+	b.setLevel(depth, event.Side, event.Price, event.Size, 1)
+
+	return func() {
+		b.add(&Order{
+			ID:    event.OrderID,
+			Side:  event.Side,
+			Price: event.Price,
+			Size:  event.Size,
+			Time:  event.Timestamp,
+		})
+	}, nil
+}
+
+// prepareModify prepares order size or price change, zero size removes order
+func (b *L3Book) prepareModify(event *OrderEvent, order *Order, depth *DepthEvent) (func(), error) {
+	if event.Size < 0 {
+		return nil, fmt.Errorf("order event(%s) %d: size must not be negative", b.Book.Symbol, event.UpdateID)
+	}
+
+	price := event.Price
+	if price == 0 {
+		price = order.Price
+	}
+
+	switch {
+	case event.Size == 0:
+		b.setLevel(depth, order.Side, order.Price, -order.Size, -1)
+
+		return func() {
+			b.remove(order)
+		}, nil
+	case price == order.Price && event.Size <= order.Size:
+		b.setLevel(depth, order.Side, order.Price, event.Size-order.Size, 0)
+
+		return func() {
+			b.queues[order.Side][order.Price].size += event.Size - order.Size
+			order.Size = event.Size
+		}, nil
+	case price == order.Price:
+		// Size increase loses priority
+		b.setLevel(depth, order.Side, order.Price, event.Size-order.Size, 0)
+	default:
+		b.setLevel(depth, order.Side, order.Price, -order.Size, -1)
+		b.setLevel(depth, order.Side, price, event.Size, 1)
+	}
+
+	return func() {
+		b.remove(order)
+		order.Price = price
+		order.Size = event.Size
+		b.add(order)
+	}, nil
+}
+
+// setLevel adds level changed by size and count deltas to depth event, emptied level is deleted
+func (b *L3Book) setLevel(depth *DepthEvent, side int, price, sizeDelta, countDelta int64) {
+	var size, count int64
+	if q, ok := b.queues[side][price]; ok {
+		size = q.size
+		count = q.count
+	}
+
+	size += sizeDelta
+	count += countDelta
+
+	if side == OrderBookSideAsks {
+		depth.Asks = append(depth.Asks, &Ask{Price: price, Quantity: size, Count: count, Delete: count == 0})
+		return
+	}
+	depth.Bids = append(depth.Bids, &Bid{Price: price, Quantity: size, Count: count, Delete: count == 0})
+}
+
+// add appends order to its price queue
+func (b *L3Book) add(order *Order) {
+	q, ok := b.queues[order.Side][order.Price]
+	if !ok {
+		q = &orderQueue{}
+		b.queues[order.Side][order.Price] = q
+	}
+
+	q.push(order)
+	b.orders[order.ID] = order
+}
+
+// remove unlinks order, empty queue is dropped
+func (b *L3Book) remove(order *Order) {
+	q := b.queues[order.Side][order.Price]
+	q.remove(order)
+	if q.count == 0 {
+		delete(b.queues[order.Side], order.Price)
+	}
+
+	delete(b.orders, order.ID)
+}
+
+// Clear drops all orders and clears Book
+func (b *L3Book) Clear() {
+	b.reset()
+	b.Book.Clear()
+}
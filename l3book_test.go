@@ -0,0 +1,233 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// checkQueue checks order IDs at price in queue order
+func checkQueue(t *testing.T, b *L3Book, side int, price int64, expected []string) {
+	t.Helper()
+
+	var ids []string
+	b.EachOrder(side, price, func(order *Order) bool {
+		ids = append(ids, order.ID)
+		return true
+	})
+
+	if len(ids) != len(expected) {
+		t.Errorf("Invalid queue %d! Expected: %v, got: %v", price, expected, ids)
+		return
+	}
+
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("Invalid queue %d! Expected: %v, got: %v", price, expected, ids)
+			return
+		}
+	}
+}
+
+// checkLevelCount checks aggregated level size and order count
+func checkLevelCount(t *testing.T, side SideReader, price, size, count int64) {
+	t.Helper()
+
+	var found *ListNode
+	side.Each(func(node *ListNode) bool {
+		if node.Price == price {
+			found = node
+			return false
+		}
+		return true
+	})
+
+	switch {
+	case found == nil && size != 0:
+		t.Errorf("Missing level %d", price)
+	case found != nil && size == 0:
+		t.Errorf("Unexpected level %d", price)
+	case found != nil && (found.Size != size || found.Count != count):
+		t.Errorf("Invalid level %d! Expected: %d/%d, got: %d/%d", price, size, count, found.Size, found.Count)
+	}
+}
+
+// testL3Book loaded order book: bids a, b @ 100 and c @ 99, asks d @ 101
+func testL3Book(t *testing.T) *L3Book {
+	b := NewL3Book("BTC-USD", WithViews())
+	b.Contiguous = true
+
+	err := b.ProcessSnapshot(&OrderSnapshot{
+		LastUpdateID: 10,
+		Bids: []*Order{
+			{ID: "a", Price: 100, Size: 5},
+			{ID: "b", Price: 100, Size: 3},
+			{ID: "c", Price: 99, Size: 2},
+		},
+		Asks: []*Order{
+			{ID: "d", Price: 101, Size: 4},
+		},
+	}, []*OrderEvent{
+		{Type: OrderEventAdd, UpdateID: 10, OrderID: "x", Side: OrderBookSideAsks, Price: 105, Size: 1},
+		{Type: OrderEventAdd, UpdateID: 11, OrderID: "e", Side: OrderBookSideAsks, Price: 102, Size: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// TestL3Book checks queues and derived levels
+func TestL3Book(t *testing.T) {
+	b := testL3Book(t)
+
+	if b.Len() != 5 || b.Book.LastUpdateID != 11 {
+		t.Errorf("Invalid order count! Expected: %d, got: %d", 5, b.Len())
+	}
+
+	checkLevelCount(t, b.Book.Bids, 100, 8, 2)
+	checkLevelCount(t, b.Book.Asks, 102, 1, 1)
+	checkLevelCount(t, b.Book.Asks, 105, 0, 0)
+	checkQueue(t, b, OrderBookSideBids, 100, []string{"a", "b"})
+
+	events := []*OrderEvent{
+		// Partial match keeps position
+		{Type: OrderEventMatch, UpdateID: 12, OrderID: "a", Size: 3},
+		// Size decrease keeps position
+		{Type: OrderEventModify, UpdateID: 13, OrderID: "b", Size: 1},
+		// Size increase loses priority
+		{Type: OrderEventModify, UpdateID: 14, OrderID: "a", Size: 4},
+	}
+	for _, event := range events {
+		if err := b.ProcessEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkLevelCount(t, b.Book.Bids, 100, 5, 2)
+	checkQueue(t, b, OrderBookSideBids, 100, []string{"b", "a"})
+
+	if ahead, size, ok := b.QueuePosition("a"); !ok || ahead != 1 || size != 1 {
+		t.Errorf("Invalid queue position! Expected: %d/%d, got: %d/%d", 1, 1, ahead, size)
+	}
+
+	events = []*OrderEvent{
+		// Price change moves order to back of new queue
+		{Type: OrderEventModify, UpdateID: 15, OrderID: "b", Price: 99, Size: 1},
+		{Type: OrderEventCancel, UpdateID: 16, OrderID: "c"},
+		// Order never rested
+		{Type: OrderEventCancel, UpdateID: 17, OrderID: "unknown"},
+		{Type: OrderEventMatch, UpdateID: 18, OrderID: "d", Size: 4},
+	}
+	for _, event := range events {
+		if err := b.ProcessEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkLevelCount(t, b.Book.Bids, 100, 4, 1)
+	checkLevelCount(t, b.Book.Bids, 99, 1, 1)
+	checkLevelCount(t, b.Book.Asks, 101, 0, 0)
+	checkQueue(t, b, OrderBookSideBids, 99, []string{"b"})
+
+	if _, ok := b.Order("d"); ok || b.Len() != 3 || b.Book.LastUpdateID != 18 {
+		t.Errorf("Invalid order count! Expected: %d, got: %d", 3, b.Len())
+	}
+
+	// View and price functions see derived levels
+	checkLevelCount(t, b.Book.View().Bids, 100, 4, 1)
+	if bbo := b.Book.BBO(); bbo.BidPrice != 100 || bbo.AskPrice != 102 {
+		t.Errorf("Invalid BBO! Got: %+v", bbo)
+	}
+}
+
+// TestL3BookRejected checks rejected events leave orders unchanged
+func TestL3BookRejected(t *testing.T) {
+	b := testL3Book(t)
+
+	err := b.ProcessEvent(&OrderEvent{Type: OrderEventAdd, UpdateID: 12, OrderID: "a", Side: OrderBookSideBids, Price: 100, Size: 1})
+	if !errors.Is(err, ErrDuplicateOrder) {
+		t.Errorf("Expected duplicate order error, got: %v", err)
+	}
+
+	if err := b.ProcessEvent(&OrderEvent{Type: OrderEventMatch, UpdateID: 12, OrderID: "a", Size: 6}); err == nil {
+		t.Errorf("Expected invalid match size error")
+	}
+
+	// Invalid levels are rejected by book
+	var invalid *ErrInvalidEvent
+	err = b.ProcessEvent(&OrderEvent{Type: OrderEventAdd, UpdateID: 12, OrderID: "f", Side: OrderBookSideBids, Price: -1, Size: 1})
+	if !errors.As(err, &invalid) {
+		t.Errorf("Expected invalid event error, got: %v", err)
+	}
+
+	if _, ok := b.Order("f"); ok || b.Len() != 5 {
+		t.Errorf("Invalid order count! Expected: %d, got: %d", 5, b.Len())
+	}
+
+	// Gap marks book not loaded
+	var gap *ErrSequenceGap
+	err = b.ProcessEvent(&OrderEvent{Type: OrderEventCancel, UpdateID: 13, OrderID: "a"})
+	if !errors.As(err, &gap) || b.Book.Loaded {
+		t.Errorf("Expected sequence gap error, got: %v", err)
+	}
+
+	if _, ok := b.Order("a"); !ok {
+		t.Errorf("Expected order to be kept")
+	}
+
+	// Invalid snapshot keeps book
+	err = b.ProcessSnapshot(&OrderSnapshot{LastUpdateID: 20, Bids: []*Order{{ID: "a", Price: 100, Size: 1}, {ID: "a", Price: 99, Size: 1}}}, nil)
+	if !errors.Is(err, ErrDuplicateOrder) || b.Len() != 5 {
+		t.Errorf("Expected duplicate order error, got: %v", err)
+	}
+
+	b.Clear()
+	if b.Len() != 0 || b.Book.Bids.Size() != 0 {
+		t.Errorf("Expected empty book after Clear")
+	}
+}
+
+// TestL3BookReplayedEvent checks replayed event does not change orders
+func TestL3BookReplayedEvent(t *testing.T) {
+	b := testL3Book(t)
+
+	event := &OrderEvent{Type: OrderEventMatch, UpdateID: 12, OrderID: "a", Size: 1}
+	if err := b.ProcessEvent(event); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.ProcessEvent(event); err == nil {
+		t.Errorf("Expected replayed event error")
+	}
+
+	if order, _ := b.Order("a"); order.Size != 4 {
+		t.Errorf("Invalid order size! Expected: %d, got: %d", 4, order.Size)
+	}
+
+	checkLevelCount(t, b.Book.Bids, 100, 7, 2)
+}
+
+// TestL3BookValueSides checks snapshot is loaded into sides that are not comparable
+func TestL3BookValueSides(t *testing.T) {
+	b := NewL3Book("BTC-USD", WithSides(newValueSide))
+
+	err := b.ProcessSnapshot(&OrderSnapshot{
+		LastUpdateID: 10,
+		Bids:         []*Order{{ID: "a", Price: 100, Size: 5}, {ID: "b", Price: 100, Size: 3}},
+		Asks:         []*Order{{ID: "c", Price: 101, Size: 4}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Len() != 3 {
+		t.Errorf("Invalid order count! Expected: %d, got: %d", 3, b.Len())
+	}
+
+	checkQueue(t, b, OrderBookSideBids, 100, []string{"a", "b"})
+	// Side keeps size only
+	if front, err := b.Book.Bids.Front(); err != nil || front.Size != 8 {
+		t.Errorf("Invalid bid level! Expected: %d, got: %v (%v)", 8, front, err)
+	}
+}
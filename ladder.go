@@ -0,0 +1,325 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Ladder struct is a tick indexed price level array for instruments with a fixed tick size.
+// Sizes are stored in a ring covering window ticks, level at price is (price - base) / tick.
+// The window recenters around the best price when it drifts to the outer quarters, levels
+// falling out of the window are dropped and updates beyond the worse edge or off tick are ignored,
+// both are counted, see Dropped.
+type Ladder struct {
+	tick    int64
+	base    int64
+	head    int
+	sizes   []int64
+	best    int
+	len     int
+	desc    bool
+	dropped int64
+}
+
+// NewLadder creates new ladder ordered for side (OrderBookSideBids descending, OrderBookSideAsks ascending)
+func NewLadder(side int, tick int64, window int) *Ladder {
+	if tick < 1 {
+		tick = 1
+	}
+
+	if window < 4 {
+		window = 4
+	}
+
+	return &Ladder{
+		tick:  tick,
+		sizes: make([]int64, window),
+		best:  -1,
+		desc:  side == OrderBookSideBids,
+	}
+}
+
+// slot returns ring position of window index
+func (l *Ladder) slot(index int) int {
+	return (l.head + index) % len(l.sizes)
+}
+
+// price of window index
+func (l *Ladder) price(index int) int64 {
+	return l.base + int64(index)*l.tick
+}
+
+// index returns window index of price, false if price is off tick or outside the window
+func (l *Ladder) index(price int64) (int, bool) {
+	offset := price - l.base
+	if offset%l.tick != 0 {
+		return 0, false
+	}
+
+	offset /= l.tick
+	if offset < 0 || offset >= int64(len(l.sizes)) {
+		return int(offset), false
+	}
+
+	return int(offset), true
+}
+
+// better reports whether window index a is ordered before window index b
+func (l *Ladder) better(a, b int) bool {
+	if l.desc {
+		return a > b
+	}
+	return a < b
+}
+
+// step returns index increment towards worse levels
+func (l *Ladder) step() int {
+	if l.desc {
+		return -1
+	}
+	return 1
+}
+
+// edge returns best possible window index
+func (l *Ladder) edge() int {
+	if l.desc {
+		return len(l.sizes) - 1
+	}
+	return 0
+}
+
+// scan finds first non empty level starting at window index, -1 if none
+func (l *Ladder) scan(from int) int {
+	step := l.step()
+	for i := from; i >= 0 && i < len(l.sizes); i += step {
+		if l.sizes[l.slot(i)] != 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// shift moves window by k ticks, dropping levels falling out of it
+func (l *Ladder) shift(k int) {
+	n := len(l.sizes)
+
+	if k >= n || -k >= n {
+		for i := range l.sizes {
+			l.sizes[i] = 0
+		}
+		l.dropped += int64(l.len)
+		l.head = 0
+		l.len = 0
+		l.best = -1
+		l.base += int64(k) * l.tick
+		return
+	}
+
+	// Clear indices leaving the window
+	from, to := 0, k
+	if k < 0 {
+		from, to = n+k, n
+	}
+
+	for i := from; i < to; i++ {
+		slot := l.slot(i)
+		if l.sizes[slot] != 0 {
+			l.sizes[slot] = 0
+			l.len--
+			l.dropped++
+		}
+	}
+
+	l.head = (l.head + k + n) % n
+	l.base += int64(k) * l.tick
+
+	// Update best
+	if l.best >= 0 {
+		l.best -= k
+		if l.best < 0 || l.best >= n {
+			l.best = l.scan(l.edge())
+		}
+	}
+}
+
+// recenter moves window so price is in the middle of it
+func (l *Ladder) recenter(price int64) {
+	offset := (price - l.base) / l.tick
+	k := offset - int64(len(l.sizes)/2)
+
+	if k >= int64(len(l.sizes)) || -k >= int64(len(l.sizes)) {
+		// Nothing survives, realign base on price
+		l.shift(len(l.sizes))
+		l.base = price - int64(len(l.sizes)/2)*l.tick
+		return
+	}
+
+	l.shift(int(k))
+}
+
+// rebalance recenters window if best level drifted to the outer quarters
+func (l *Ladder) rebalance() {
+	n := len(l.sizes)
+	if l.best < 0 || (l.best >= n/4 && l.best < n-n/4) {
+		return
+	}
+
+	l.recenter(l.price(l.best))
+}
+
+// UpdateOrAdd level, zero size removes it. Prices off tick or beyond worse edge are ignored and counted.
+func (l *Ladder) UpdateOrAdd(price, size int64) {
+	if size == 0 {
+		l.Remove(price)
+		return
+	}
+
+	if l.len == 0 {
+		// Empty ladder, center window on price
+		l.head = 0
+		l.base = price - int64(len(l.sizes)/2)*l.tick
+		l.best = -1
+	}
+
+	index, ok := l.index(price)
+	if !ok {
+		if (price-l.base)%l.tick != 0 {
+			l.dropped++
+			return
+		}
+
+		// Beyond worse edge
+		if !l.better(index, l.best) {
+			l.dropped++
+			return
+		}
+
+		l.recenter(price)
+		index, _ = l.index(price)
+	}
+
+	slot := l.slot(index)
+	if l.sizes[slot] == 0 {
+		l.len++
+	}
+	l.sizes[slot] = size
+
+	if l.best < 0 || l.better(index, l.best) {
+		l.best = index
+		l.rebalance()
+	}
+}
+
+// Remove level
+func (l *Ladder) Remove(price int64) error {
+	if l.len == 0 {
+		return fmt.Errorf("Remove: List is empty, %w", ErrLevelNotFound)
+	}
+
+	index, ok := l.index(price)
+	if !ok || l.sizes[l.slot(index)] == 0 {
+		return fmt.Errorf("Remove: %w", ErrLevelNotFound)
+	}
+
+	l.sizes[l.slot(index)] = 0
+	l.len--
+
+	if index == l.best {
+		l.best = l.scan(index)
+		l.rebalance()
+	}
+
+	return nil
+}
+
+// Prune levels, keeps first length levels
+func (l *Ladder) Prune(length int) {
+	if length < 1 || length >= l.len {
+		return
+	}
+
+	count := 0
+	step := l.step()
+	for i := l.best; i >= 0 && i < len(l.sizes); i += step {
+		slot := l.slot(i)
+		if l.sizes[slot] == 0 {
+			continue
+		}
+
+		if count == length {
+			l.sizes[slot] = 0
+			continue
+		}
+
+		count++
+	}
+
+	l.len = length
+}
+
+// Front level
+func (l *Ladder) Front() (*ListNode, error) {
+	if l.best < 0 {
+		return nil, errors.New("Front: List is empty")
+	}
+
+	return &ListNode{
+		Price: l.price(l.best),
+		Size:  l.sizes[l.slot(l.best)],
+	}, nil
+}
+
+// Last level
+func (l *Ladder) Last() (*ListNode, error) {
+	if l.best < 0 {
+		return nil, errors.New("Last: List is empty")
+	}
+
+	step := l.step()
+	last := l.best
+	for i := l.best; i >= 0 && i < len(l.sizes); i += step {
+		if l.sizes[l.slot(i)] != 0 {
+			last = i
+		}
+	}
+
+	return &ListNode{
+		Price: l.price(last),
+		Size:  l.sizes[l.slot(last)],
+	}, nil
+}
+
+// Each calls fn for every level in priority order until fn returns false, node is reused between calls
+func (l *Ladder) Each(fn func(node *ListNode) bool) {
+	if l.best < 0 {
+		return
+	}
+
+	var node ListNode
+
+	step := l.step()
+	for i := l.best; i >= 0 && i < len(l.sizes); i += step {
+		size := l.sizes[l.slot(i)]
+		if size == 0 {
+			continue
+		}
+
+		node.Price = l.price(i)
+		node.Size = size
+
+		if !fn(&node) {
+			return
+		}
+	}
+}
+
+// Dropped returns number of levels dropped by window moves and ignored updates off tick or beyond worse edge
+func (l *Ladder) Dropped() int64 {
+	return l.dropped
+}
+
+// Size of ladder
+func (l *Ladder) Size() int {
+	return l.len
+}
@@ -0,0 +1,235 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// ladderPrices returns ladder levels in priority order
+func ladderPrices(l *Ladder) []int64 {
+	prices := []int64{}
+	l.Each(func(node *ListNode) bool {
+		prices = append(prices, node.Price)
+		return true
+	})
+	return prices
+}
+
+// TestLadderUpdateOrAdd levels
+func TestLadderUpdateOrAdd(t *testing.T) {
+	asks := NewLadder(OrderBookSideAsks, 5, 1000)
+
+	// Operations
+	asks.UpdateOrAdd(124005, 100)
+	asks.UpdateOrAdd(124000, 100)
+	asks.UpdateOrAdd(123000, 200)
+	asks.UpdateOrAdd(123500, 3300)
+	asks.UpdateOrAdd(124000, 200)
+
+	// Off tick price is ignored
+	asks.UpdateOrAdd(124001, 200)
+
+	if asks.Size() != 4 || asks.Dropped() != 1 {
+		t.Errorf("Invalid level count! Expected: %d, got: %d (%d dropped)", 4, asks.Size(), asks.Dropped())
+		return
+	}
+
+	front, _ := asks.Front()
+	if front.Price != 123000 || front.Size != 200 {
+		t.Errorf("Invalid front level! Expected: %d, got: %d", 123000, front.Price)
+	}
+
+	expected := []int64{123000, 123500, 124000, 124005}
+	prices := ladderPrices(asks)
+	for i, price := range expected {
+		if prices[i] != price {
+			t.Errorf("Invalid level %d! Expected: %d, got: %d", i, price, prices[i])
+		}
+	}
+
+	bids := NewLadder(OrderBookSideBids, 5, 1000)
+	bids.UpdateOrAdd(124005, 100)
+	bids.UpdateOrAdd(124000, 100)
+	bids.UpdateOrAdd(123500, 3300)
+	bids.UpdateOrAdd(123000, 200)
+	bids.UpdateOrAdd(123500, 78012)
+
+	expected = []int64{124005, 124000, 123500, 123000}
+	prices = ladderPrices(bids)
+	if len(prices) != len(expected) {
+		t.Errorf("Invalid level count! Expected: %d, got: %d", len(expected), len(prices))
+		return
+	}
+
+	for i, price := range expected {
+		if prices[i] != price {
+			t.Errorf("Invalid level %d! Expected: %d, got: %d", i, price, prices[i])
+		}
+	}
+}
+
+// TestLadderRemove levels
+func TestLadderRemove(t *testing.T) {
+	asks := NewLadder(OrderBookSideAsks, 1, 64)
+	for price := int64(100); price < 110; price++ {
+		asks.UpdateOrAdd(price, 1)
+	}
+
+	if err := asks.Remove(100); err != nil {
+		t.Error(err)
+	}
+
+	if err := asks.Remove(100); err == nil {
+		t.Errorf("Expected an error")
+	}
+
+	front, _ := asks.Front()
+	if front.Price != 101 || asks.Size() != 9 {
+		t.Errorf("Invalid front level! Expected: %d, got: %d", 101, front.Price)
+	}
+
+	// Zero size removes level
+	asks.UpdateOrAdd(101, 0)
+
+	front, _ = asks.Front()
+	if front.Price != 102 || asks.Size() != 8 {
+		t.Errorf("Invalid front level! Expected: %d, got: %d", 102, front.Price)
+	}
+
+	// Prune
+	asks.Prune(3)
+
+	expected := []int64{102, 103, 104}
+	prices := ladderPrices(asks)
+	if len(prices) != len(expected) || asks.Size() != 3 {
+		t.Errorf("Invalid level count! Expected: %d, got: %d", len(expected), len(prices))
+		return
+	}
+
+	for i, price := range expected {
+		if prices[i] != price {
+			t.Errorf("Invalid level %d! Expected: %d, got: %d", i, price, prices[i])
+		}
+	}
+}
+
+// TestLadderRecenter window
+func TestLadderRecenter(t *testing.T) {
+	bids := NewLadder(OrderBookSideBids, 1, 32)
+	for price := int64(1000); price > 992; price-- {
+		bids.UpdateOrAdd(price, 1)
+	}
+
+	// Update beyond worse edge is ignored
+	bids.UpdateOrAdd(900, 1)
+	if bids.Size() != 8 {
+		t.Errorf("Invalid level count! Expected: %d, got: %d", 8, bids.Size())
+	}
+
+	// Price drifts up, worst levels fall out of the window
+	bids.UpdateOrAdd(1010, 1)
+
+	front, _ := bids.Front()
+	if front.Price != 1010 || bids.Size() != 8 {
+		t.Errorf("Invalid front level! Expected: %d, got: %d", 1010, front.Price)
+	}
+
+	for _, price := range ladderPrices(bids) {
+		if price < 1010-16 {
+			t.Errorf("Level %d outside of window", price)
+		}
+	}
+
+	// Remove best levels, window follows price down
+	bids.Remove(1010)
+	for price := int64(1000); price > 996; price-- {
+		bids.Remove(price)
+	}
+
+	front, _ = bids.Front()
+	if front.Price != 996 {
+		t.Errorf("Invalid front level! Expected: %d, got: %d", 996, front.Price)
+	}
+
+	bids.UpdateOrAdd(985, 7)
+	last, _ := bids.Last()
+	if last.Price != 985 || last.Size != 7 {
+		t.Errorf("Invalid last level! Expected: %d, got: %d", 985, last.Price)
+	}
+}
+
+// TestOrderBookWithLadder sides
+func TestOrderBookWithLadder(t *testing.T) {
+	ob := New("BTCUSDT", 10, WithLadder(1000000, 1024))
+
+	if _, ok := ob.Asks.(*Ladder); !ok {
+		t.Errorf("Expected ladder asks")
+	}
+
+	ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 4800001000000, Quantity: 1000000}},
+		Bids:         []*Bid{{Price: 4799999000000, Quantity: 1000000}},
+	}, nil)
+
+	price, err := ob.GetMarketPrice()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if price.String() != "48000" {
+		t.Errorf("Invalid market price! Expected: %s, got: %s", "48000", price.String())
+	}
+}
+
+// TestOrderBookWithLadderRejected checks off tick levels are rejected and out of window levels counted
+func TestOrderBookWithLadderRejected(t *testing.T) {
+	ob := New("X", 0, WithLadder(1, 8), WithViews())
+
+	err := ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 100, Quantity: 1}, {Price: 110, Quantity: 1}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ob.Asks.Size() != 1 || ob.Dropped() != 1 {
+		t.Errorf("Invalid dropped levels! Expected: %d, got: %d", 1, ob.Dropped())
+	}
+
+	ob = New("X", 0, WithLadder(100, 64), WithViews())
+	if ob.Instrument.TickSize != 100 {
+		t.Errorf("Invalid tick size! Expected: %d, got: %d", 100, ob.Instrument.TickSize)
+	}
+
+	var invalid *ErrInvalidEvent
+	err = ob.ProcessSnapshot(&DepthSnapshot{
+		LastUpdateID: 1,
+		Asks:         []*Ask{{Price: 1000, Quantity: 1}, {Price: 1050, Quantity: 1}},
+	}, nil)
+	if !errors.As(err, &invalid) || len(invalid.Levels) != 1 || invalid.Levels[0].Price != 1050 {
+		t.Errorf("Expected off tick level rejected, got: %v", err)
+	}
+
+	// Instrument tick size is kept aligned to ladder tick
+	ob = New("X", 0, WithInstrument(Instrument{TickSize: 150}), WithLadder(100, 64))
+	if ob.Instrument.TickSize != 300 {
+		t.Errorf("Invalid tick size! Expected: %d, got: %d", 300, ob.Instrument.TickSize)
+	}
+}
+
+// BenchmarkLadderUpdateOrAdd benchmarks Ladder updates on deep book
+func BenchmarkLadderUpdateOrAdd(b *testing.B) {
+	ladder := NewLadder(OrderBookSideAsks, 1, 4*benchmarkDepth)
+	for price := int64(100000); price < 100000+benchmarkDepth; price++ {
+		ladder.UpdateOrAdd(price, 1)
+	}
+	prices := benchmarkUpdates(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ladder.UpdateOrAdd(prices[i&1023], int64(i)+1)
+	}
+}
@@ -2,6 +2,8 @@ package orderbook
 
 import (
 	"errors"
+	"fmt"
+	"time"
 )
 
 const (
@@ -15,14 +17,60 @@ const (
 type ListNode struct {
 	Price int64
 	Size  int64
+	// Count orders at level, 0 if unknown
+	Count int64
+	// UpdateID of snapshot or event that last set level, 0 if unknown
+	UpdateID int64
+	// UpdatedAt time level was last set, zero if unknown
+	UpdatedAt time.Time
 
 	next *ListNode
+	// Higher level forward pointers, used by SkipList
+	skip []*ListNode
+}
+
+// Next node in list order, nil for last node
+func (n *ListNode) Next() *ListNode {
+	return n.next
+}
+
+// set copies level size and metadata
+func (n *ListNode) set(level ListNode) {
+	n.Size = level.Size
+	n.Count = level.Count
+	n.UpdateID = level.UpdateID
+	n.UpdatedAt = level.UpdatedAt
+}
+
+// forward returns next node on skip list level
+func (n *ListNode) forward(level int) *ListNode {
+	if level == 0 {
+		return n.next
+	}
+	return n.skip[level-1]
+}
+
+// setForward sets next node on skip list level
+func (n *ListNode) setForward(level int, node *ListNode) {
+	if level == 0 {
+		n.next = node
+		return
+	}
+	n.skip[level-1] = node
 }
 
 // List struct
 type List struct {
 	len  int
 	head *ListNode
+	desc bool
+}
+
+// NewList creates new list ordered for side (OrderBookSideBids descending, OrderBookSideAsks ascending)
+func NewList(side int) *List {
+	return &List{
+		desc: side == OrderBookSideBids,
+	}
 }
 
 // AddFront node
@@ -62,12 +110,32 @@ func (l *List) AddBack(price, size int64) {
 	l.len++
 }
 
+// UpdateOrAdd node keeping list order
+func (l *List) UpdateOrAdd(price, size int64) {
+	l.SetLevel(ListNode{Price: price, Size: size})
+}
+
+// SetLevel updates or adds node keeping list order
+func (l *List) SetLevel(level ListNode) {
+	if l.desc {
+		l.setLevelDesc(level)
+		return
+	}
+	l.setLevelAsc(level)
+}
+
 // UpdateOrAddAsc node
 func (l *List) UpdateOrAddAsc(price, size int64) {
+	l.setLevelAsc(ListNode{Price: price, Size: size})
+}
+
+// setLevelAsc updates or adds node in ascending list
+func (l *List) setLevelAsc(level ListNode) {
+	price := level.Price
 	node := &ListNode{
 		Price: price,
-		Size:  size,
 	}
+	node.set(level)
 
 	// Empty list
 	if l.head == nil {
@@ -91,8 +159,7 @@ func (l *List) UpdateOrAddAsc(price, size int64) {
 	for current != nil {
 		if current.Price == price {
 			// Found node! Update current node.
-			current.Price = price
-			current.Size = size
+			current.set(level)
 			break
 		} else if price > current.Price {
 			// Validate next
@@ -116,10 +183,16 @@ func (l *List) UpdateOrAddAsc(price, size int64) {
 
 // UpdateOrAddDesc node
 func (l *List) UpdateOrAddDesc(price, size int64) {
+	l.setLevelDesc(ListNode{Price: price, Size: size})
+}
+
+// setLevelDesc updates or adds node in descending list
+func (l *List) setLevelDesc(level ListNode) {
+	price := level.Price
 	node := &ListNode{
 		Price: price,
-		Size:  size,
 	}
+	node.set(level)
 
 	// Empty list
 	if l.head == nil {
@@ -143,8 +216,7 @@ func (l *List) UpdateOrAddDesc(price, size int64) {
 	for current != nil {
 		if current.Price == price {
 			// Found node! Update current node.
-			current.Price = price
-			current.Size = size
+			current.set(level)
 			break
 		} else if price < current.Price {
 			// Validate next
@@ -186,7 +258,7 @@ func (l *List) Prune(length int) {
 func (l *List) Remove(price int64) error {
 
 	if l.head == nil {
-		return errors.New("Remove: List is empty")
+		return fmt.Errorf("Remove: List is empty, %w", ErrLevelNotFound)
 	}
 
 	removed := false
@@ -217,7 +289,7 @@ func (l *List) Remove(price int64) error {
 	}
 
 	if !removed {
-		return errors.New("Remove: node not found")
+		return fmt.Errorf("Remove: %w", ErrLevelNotFound)
 	}
 
 	return nil
@@ -290,3 +362,12 @@ func (l *List) Last() (*ListNode, error) {
 func (l *List) Size() int {
 	return l.len
 }
+
+// Each calls fn for every node in list order until fn returns false
+func (l *List) Each(fn func(node *ListNode) bool) {
+	for node := l.head; node != nil; node = node.next {
+		if !fn(node) {
+			return
+		}
+	}
+}
@@ -193,3 +193,32 @@ func TestRemove(t *testing.T) {
 		t.Errorf("Invalid last node! Expected: %d, got: %d", 100010, list.head.next.Price)
 	}
 }
+
+// TestUpdateOrAdd node keeps side order
+func TestUpdateOrAdd(t *testing.T) {
+	asks := NewList(OrderBookSideAsks)
+	bids := NewList(OrderBookSideBids)
+
+	for _, price := range []int64{124000, 123000, 124005, 123500} {
+		asks.UpdateOrAdd(price, 100)
+		bids.UpdateOrAdd(price, 100)
+	}
+
+	if asks.head.Price != 123000 {
+		t.Errorf("Invalid ask head node! Expected: %d, got: %d", 123000, asks.head.Price)
+	}
+
+	if bids.head.Price != 124005 {
+		t.Errorf("Invalid bid head node! Expected: %d, got: %d", 124005, bids.head.Price)
+	}
+
+	count := 0
+	bids.Each(func(node *ListNode) bool {
+		count++
+		return node.Price > 123500
+	})
+
+	if count != 3 {
+		t.Errorf("Invalid iteration count! Expected: %d, got: %d", 3, count)
+	}
+}
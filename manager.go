@@ -0,0 +1,259 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrMissingSymbol is returned when routed snapshot or event has no symbol
+var ErrMissingSymbol = errors.New("missing symbol")
+
+// BookManager routes snapshots and events to per symbol order books created on demand.
+// Every book has its own lock, with snapshot fetcher events are synchronized per symbol and snapshots
+// are fetched without holding book lock, so book readers and health reports do not wait for fetches.
+type BookManager struct {
+	pruneThreshold int
+	options        []Option
+	fetcher        SnapshotFetcher
+
+	// OnStateChange is called on symbol sync state transitions, must be set before books are created
+	OnStateChange func(symbol string, from, to SyncState)
+	// Instruments per symbol, symbols not listed use DefaultInstrument or WithInstrument option.
+	// Must be set before books are created.
+	Instruments map[string]Instrument
+
+	mu    sync.RWMutex
+	books map[string]*managedBook
+}
+
+// managedBook is book with its synchronizer, sync is guarded by syncMu and subscriptions by manager lock
+type managedBook struct {
+	book          *ConcurrentOrderBook
+	sync          *Synchronizer
+	syncMu        sync.Mutex
+	subscriptions []*Subscription
+}
+
+// NewBookManager creates new struct instance of *BookManager, fetcher may be nil when snapshots are routed by caller
+func NewBookManager(pruneThreshold int, fetcher SnapshotFetcher, options ...Option) *BookManager {
+	return &BookManager{
+		pruneThreshold: pruneThreshold,
+		options:        options,
+		fetcher:        fetcher,
+		books:          make(map[string]*managedBook),
+	}
+}
+
+// get returns symbol book, creates it if create is set
+func (m *BookManager) get(symbol string, create bool) *managedBook {
+	m.mu.RLock()
+	entry, ok := m.books[symbol]
+	m.mu.RUnlock()
+
+	if ok || !create {
+		return entry
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry, ok := m.books[symbol]; ok {
+		return entry
+	}
+
+	options := m.options
+	if instrument, ok := m.Instruments[symbol]; ok {
+		options = append(options[:len(options):len(options)], WithInstrument(instrument))
+	}
+
+	ob := New(symbol, m.pruneThreshold, options...)
+	entry = &managedBook{
+		book: WrapConcurrent(ob),
+	}
+
+	if m.fetcher != nil {
+		entry.sync = NewSynchronizer(ob, m.fetcher)
+		entry.sync.lock = entry.book.Write
+
+		if m.OnStateChange != nil {
+			onStateChange := m.OnStateChange
+			entry.sync.OnStateChange = func(from, to SyncState) {
+				onStateChange(symbol, from, to)
+			}
+		}
+	}
+
+	m.books[symbol] = entry
+
+	return entry
+}
+
+// Add creates symbol book if it does not exist
+func (m *BookManager) Add(symbol string) *ConcurrentOrderBook {
+	return m.get(symbol, true).book
+}
+
+// Book returns symbol book, nil if not tracked
+func (m *BookManager) Book(symbol string) *ConcurrentOrderBook {
+	entry := m.get(symbol, false)
+	if entry == nil {
+		return nil
+	}
+	return entry.book
+}
+
+// Subscribe registers subscription to symbol book, book is created if it does not exist
+func (m *BookManager) Subscribe(symbol string, buffer int, policy BackPressure) *Subscription {
+	entry := m.get(symbol, true)
+	return m.track(entry, entry.book.Subscribe(buffer, policy))
+}
+
+// SubscribeFunc registers callback subscription to symbol book, book is created if it does not exist
+func (m *BookManager) SubscribeFunc(symbol string, buffer int, policy BackPressure, fn func(update *BookUpdate)) *Subscription {
+	entry := m.get(symbol, true)
+	return m.track(entry, entry.book.SubscribeFunc(buffer, policy, fn))
+}
+
+// track keeps subscription to be closed on Remove
+func (m *BookManager) track(entry *managedBook, s *Subscription) *Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry.subscriptions = append(entry.subscriptions, s)
+
+	return s
+}
+
+// Remove stops tracking symbol and closes subscriptions made through manager, false if not tracked
+func (m *BookManager) Remove(symbol string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.books[symbol]
+	if !ok {
+		return false
+	}
+
+	// Book lock is not needed, closing unblocks pending sends
+	for _, s := range entry.subscriptions {
+		s.Close()
+	}
+	delete(m.books, symbol)
+
+	return true
+}
+
+// Symbols returns tracked symbols in order
+func (m *BookManager) Symbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	symbols := make([]string, 0, len(m.books))
+	for symbol := range m.books {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	return symbols
+}
+
+// ProcessSnapshot routes snapshot to symbol book, through synchronizer when manager has snapshot fetcher
+func (m *BookManager) ProcessSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) error {
+	if snapshot.Symbol == "" {
+		return ErrMissingSymbol
+	}
+
+	entry := m.get(snapshot.Symbol, true)
+	if entry.sync == nil {
+		return entry.book.ProcessSnapshot(snapshot, eventBuffer)
+	}
+
+	entry.syncMu.Lock()
+	defer entry.syncMu.Unlock()
+
+	return entry.sync.ProcessSnapshot(snapshot, eventBuffer)
+}
+
+// ProcessEvent routes event to symbol book, through synchronizer when manager has snapshot fetcher
+func (m *BookManager) ProcessEvent(event *DepthEvent) error {
+	if event.Symbol == "" {
+		return ErrMissingSymbol
+	}
+
+	entry := m.get(event.Symbol, true)
+	if entry.sync == nil {
+		return entry.book.ProcessEvent(event)
+	}
+
+	entry.syncMu.Lock()
+	defer entry.syncMu.Unlock()
+
+	return entry.sync.HandleEvent(event)
+}
+
+// Resync forces symbol resync, false if symbol is not tracked or manager has no snapshot fetcher
+func (m *BookManager) Resync(symbol string) bool {
+	entry := m.get(symbol, false)
+	if entry == nil || entry.sync == nil {
+		return false
+	}
+
+	entry.syncMu.Lock()
+	defer entry.syncMu.Unlock()
+
+	entry.sync.Resync()
+
+	return true
+}
+
+// SyncState returns symbol sync state, false if symbol is not tracked or manager has no snapshot fetcher
+func (m *BookManager) SyncState(symbol string) (SyncState, bool) {
+	entry := m.get(symbol, false)
+	if entry == nil || entry.sync == nil {
+		return SyncStateSyncing, false
+	}
+
+	return entry.sync.State(), true
+}
+
+// Unloaded returns symbols whose book is not loaded
+func (m *BookManager) Unloaded() []string {
+	return m.filter(func(ob *OrderBook) bool {
+		return !ob.Loaded
+	})
+}
+
+// Stale returns loaded symbols not updated within maxAge
+func (m *BookManager) Stale(maxAge time.Duration) []string {
+	now := time.Now()
+	return m.filter(func(ob *OrderBook) bool {
+		return ob.Loaded && now.Sub(ob.UpdatedAt) > maxAge
+	})
+}
+
+// filter returns symbols matching fn in order
+func (m *BookManager) filter(fn func(ob *OrderBook) bool) []string {
+	m.mu.RLock()
+	entries := make(map[string]*managedBook, len(m.books))
+	for symbol, entry := range m.books {
+		entries[symbol] = entry
+	}
+	m.mu.RUnlock()
+
+	symbols := []string{}
+	for symbol, entry := range entries {
+		match := false
+		entry.book.Read(func(ob *OrderBook) {
+			match = fn(ob)
+		})
+
+		if match {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	return symbols
+}
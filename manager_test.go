@@ -0,0 +1,182 @@
+package orderbook
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestBookManager checks routing by symbol and health reporting
+func TestBookManager(t *testing.T) {
+	m := NewBookManager(10, nil)
+
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		snapshot := testSnapshot()
+		snapshot.Symbol = symbol
+		if err := m.ProcessSnapshot(snapshot, nil); err != nil {
+			t.Error(err)
+		}
+	}
+	m.Add("SOLUSDT")
+
+	if symbols := m.Symbols(); len(symbols) != 3 || symbols[0] != "BTCUSDT" {
+		t.Errorf("Invalid symbols! Expected: %d, got: %v", 3, symbols)
+	}
+
+	if err := m.ProcessEvent(testEvent(101, 101)); err != nil {
+		t.Error(err)
+	}
+
+	if id := m.Book("BTCUSDT").LastUpdateID(); id != 101 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 101, id)
+	}
+
+	if id := m.Book("ETHUSDT").LastUpdateID(); id != 100 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 100, id)
+	}
+
+	if unloaded := m.Unloaded(); len(unloaded) != 1 || unloaded[0] != "SOLUSDT" {
+		t.Errorf("Invalid unloaded symbols! Expected: [SOLUSDT], got: %v", unloaded)
+	}
+
+	if stale := m.Stale(time.Hour); len(stale) != 0 {
+		t.Errorf("Invalid stale symbols! Expected: [], got: %v", stale)
+	}
+
+	if err := m.ProcessEvent(&DepthEvent{FinalUpdateID: 1}); !errors.Is(err, ErrMissingSymbol) {
+		t.Errorf("Expected missing symbol error, got: %v", err)
+	}
+
+	if !m.Remove("SOLUSDT") || m.Remove("SOLUSDT") || m.Book("SOLUSDT") != nil {
+		t.Errorf("Expected SOLUSDT removed once")
+	}
+}
+
+// TestBookManagerSync checks per symbol synchronization, run with -race
+func TestBookManagerSync(t *testing.T) {
+	symbols := []string{"BTCUSDT", "ETHUSDT", "SOLUSDT", "XRPUSDT"}
+
+	fetcher := SnapshotFetcherFunc(func(symbol string) (*DepthSnapshot, error) {
+		snapshot := testSnapshot()
+		snapshot.Symbol = symbol
+		return snapshot, nil
+	})
+
+	var mu sync.Mutex
+	live := map[string]bool{}
+
+	m := NewBookManager(10, fetcher)
+	m.OnStateChange = func(symbol string, from, to SyncState) {
+		mu.Lock()
+		defer mu.Unlock()
+		live[symbol] = to == SyncStateLive
+	}
+
+	var wg sync.WaitGroup
+	for _, symbol := range symbols {
+		wg.Add(1)
+		go func(symbol string) {
+			defer wg.Done()
+
+			for i := int64(101); i <= 200; i++ {
+				event := testEvent(i, i)
+				event.Symbol = symbol
+				if err := m.ProcessEvent(event); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(symbol)
+	}
+	wg.Wait()
+
+	for _, symbol := range symbols {
+		if state, ok := m.SyncState(symbol); !ok || state != SyncStateLive || !live[symbol] {
+			t.Errorf("Invalid %s state! Expected: %s, got: %s", symbol, SyncStateLive, state)
+		}
+
+		if id := m.Book(symbol).LastUpdateID(); id != 200 {
+			t.Errorf("Invalid %s update ID! Expected: %d, got: %d", symbol, 200, id)
+		}
+	}
+}
+
+// TestBookManagerRoutedSnapshot checks snapshot routed by caller makes synchronized book live
+func TestBookManagerRoutedSnapshot(t *testing.T) {
+	fetches := 0
+	fetcher := SnapshotFetcherFunc(func(symbol string) (*DepthSnapshot, error) {
+		fetches++
+		return nil, errors.New("unexpected fetch")
+	})
+
+	m := NewBookManager(10, fetcher)
+
+	snapshot := testSnapshot()
+	snapshot.Symbol = "BTCUSDT"
+	if err := m.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if state, _ := m.SyncState("BTCUSDT"); state != SyncStateLive {
+		t.Errorf("Invalid state! Expected: %s, got: %s", SyncStateLive, state)
+	}
+
+	event := testEvent(101, 101)
+	event.Symbol = "BTCUSDT"
+	if err := m.ProcessEvent(event); err != nil {
+		t.Error(err)
+	}
+
+	if fetches != 0 || m.Book("BTCUSDT").LastUpdateID() != 101 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d (fetches: %d)", 101, m.Book("BTCUSDT").LastUpdateID(), fetches)
+	}
+}
+
+// TestBookManagerFetchUnlocked checks book readers do not wait for snapshot fetch
+func TestBookManagerFetchUnlocked(t *testing.T) {
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+
+	fetcher := SnapshotFetcherFunc(func(symbol string) (*DepthSnapshot, error) {
+		close(fetching)
+		<-release
+
+		snapshot := testSnapshot()
+		snapshot.Symbol = symbol
+		return snapshot, nil
+	})
+
+	m := NewBookManager(10, fetcher)
+
+	done := make(chan error)
+	go func() {
+		event := testEvent(101, 101)
+		done <- m.ProcessEvent(event)
+	}()
+	<-fetching
+
+	// Readers and health reports return while fetch is pending
+	read := make(chan struct{})
+	go func() {
+		m.Book("BTCUSDT").Loaded()
+		m.Unloaded()
+		m.SyncState("BTCUSDT")
+		close(read)
+	}()
+
+	select {
+	case <-read:
+	case <-time.After(time.Second):
+		t.Fatal("Book readers blocked by snapshot fetch")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if state, _ := m.SyncState("BTCUSDT"); state != SyncStateLive {
+		t.Errorf("Invalid state! Expected: %s, got: %s", SyncStateLive, state)
+	}
+}
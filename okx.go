@@ -0,0 +1,110 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// okxMessage books channel push
+type okxMessage struct {
+	Arg struct {
+		Channel string `json:"channel"`
+		InstID  string `json:"instId"`
+	} `json:"arg"`
+	Action string    `json:"action"`
+	Data   []okxBook `json:"data"`
+}
+
+// okxBook snapshot or update, levels are [price, size, deprecated, orders]
+type okxBook struct {
+	Asks      [][]string `json:"asks"`
+	Bids      [][]string `json:"bids"`
+	Timestamp string     `json:"ts"`
+	Checksum  int32      `json:"checksum"`
+	PrevSeqID int64      `json:"prevSeqId"`
+	SeqID     int64      `json:"seqId"`
+}
+
+// OKXAdapter converts OKX books, books50-l2-tbt and books-l2-tbt channel messages. seqId is mapped to
+// FinalUpdateID and prevSeqId to PrevUpdateID, so OrderBook checks every update continues the previous one
+// and marks book not loaded on gap. "No change" heartbeat updates (seqId equal to prevSeqId) carry no levels
+// and only verify continuity and checksum.
+type OKXAdapter struct {
+	Decoder LevelDecoder
+}
+
+// NewOKXAdapter creates new struct instance of *OKXAdapter
+func NewOKXAdapter(decoder LevelDecoder) *OKXAdapter {
+	return &OKXAdapter{
+		Decoder: decoder,
+	}
+}
+
+// NewBook creates book verifying OKX checksums
+func (a *OKXAdapter) NewBook(symbol string, pruneThreshold int, options ...Option) *OrderBook {
+	return a.Decoder.newBook(symbol, pruneThreshold, options, WithChecksum(ChecksumConfig{Scheme: ChecksumOKX}))
+}
+
+// Decode converts snapshot push to DepthSnapshot and update push to DepthEvent, both carrying OKX checksum.
+// Other messages (subscribe responses, errors, other channels) return nil snapshot and event.
+func (a *OKXAdapter) Decode(data []byte) (*DepthSnapshot, *DepthEvent, error) {
+	var msg okxMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, nil, fmt.Errorf("okx books: %w", err)
+	}
+
+	if msg.Action != "snapshot" && msg.Action != "update" {
+		return nil, nil, nil
+	}
+
+	symbol := msg.Arg.InstID
+
+	// data is an array but OKX books channels push one instrument book per message
+	if len(msg.Data) != 1 {
+		return nil, nil, fmt.Errorf("okx books(%s): expected 1 book, got %d", symbol, len(msg.Data))
+	}
+	book := &msg.Data[0]
+
+	asks, err := a.Decoder.AskLevels(book.Asks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("okx books(%s) %d: %w", symbol, book.SeqID, err)
+	}
+
+	bids, err := a.Decoder.BidLevels(book.Bids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("okx books(%s) %d: %w", symbol, book.SeqID, err)
+	}
+
+	if msg.Action == "snapshot" {
+		return &DepthSnapshot{
+			Symbol:       symbol,
+			LastUpdateID: book.SeqID,
+			Asks:         asks,
+			Bids:         bids,
+			Checksum:     uint32(book.Checksum),
+			HasChecksum:  true,
+		}, nil, nil
+	}
+
+	event := &DepthEvent{
+		Symbol:        symbol,
+		FinalUpdateID: book.SeqID,
+		PrevUpdateID:  book.PrevSeqID,
+		Asks:          asks,
+		Bids:          bids,
+		Checksum:      uint32(book.Checksum),
+		HasChecksum:   true,
+	}
+
+	if book.Timestamp != "" {
+		ms, err := strconv.ParseInt(book.Timestamp, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("okx books(%s) %d: %w", symbol, book.SeqID, err)
+		}
+		event.Timestamp = time.UnixMilli(ms)
+	}
+
+	return nil, event, nil
+}
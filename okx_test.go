@@ -0,0 +1,88 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestOKXAdapter applies hand-written books pushes through synchronizer, last push skips sequence IDs
+func TestOKXAdapter(t *testing.T) {
+	adapter := NewOKXAdapter(DefaultLevelDecoder)
+	ob := adapter.NewBook("BTC-USDT", 400)
+
+	lines := readFixtureLines(t, "okx/books.jsonl")
+
+	// Snapshot is pushed on subscribe, resync fetches it again
+	fetcher := &fakeFetcher{}
+	sync := NewSynchronizer(ob, fetcher)
+
+	var states []SyncState
+	sync.OnStateChange = func(from, to SyncState) {
+		states = append(states, to)
+	}
+
+	var errs []error
+	for _, line := range lines {
+		snapshot, event, err := adapter.Decode(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if snapshot != nil {
+			fetcher.snapshots = append(fetcher.snapshots, snapshot)
+		}
+
+		if event != nil {
+			errs = append(errs, sync.HandleEvent(event))
+		}
+	}
+
+	for i, err := range errs[:3] {
+		if err != nil {
+			t.Errorf("Invalid update %d! Expected no error, got: %v", i, err)
+		}
+	}
+
+	if len(states) != 2 || states[0] != SyncStateLive || states[1] != SyncStateResyncing {
+		t.Errorf("Invalid states! Expected: [Live Resyncing], got: %v", states)
+	}
+
+	if ob.LastUpdateID != 123470 || ob.Loaded {
+		t.Errorf("Invalid book! Expected: %d not loaded, got: %d loaded %t", 123470, ob.LastUpdateID, ob.Loaded)
+	}
+
+	checkLevels(t, "bids", ob.Bids, [][2]int64{{847699000000, 250000}, {847697000000, 300000000}, {847555000000, 101000000}, {847554000000, 100000000}})
+}
+
+// TestOKXHeartbeat checks no change updates and sequence gaps on book
+func TestOKXHeartbeat(t *testing.T) {
+	adapter := NewOKXAdapter(DefaultLevelDecoder)
+	ob := adapter.NewBook("BTC-USDT", 400)
+
+	lines := readFixtureLines(t, "okx/books.jsonl")
+	snapshot, _, _ := adapter.Decode(lines[1])
+	if err := ob.ProcessSnapshot(snapshot, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Heartbeat must follow previous update
+	_, heartbeat, _ := adapter.Decode(lines[3])
+
+	var gap *ErrSequenceGap
+	if err := ob.ProcessEvent(heartbeat); !errors.As(err, &gap) || gap.Expected != 123456 || gap.Actual != 123461 {
+		t.Errorf("Expected sequence gap, got: %v", err)
+	}
+
+	ob.ProcessSnapshot(snapshot, nil)
+	for _, line := range lines[2:4] {
+		_, event, _ := adapter.Decode(line)
+		if err := ob.ProcessEvent(event); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Repeated heartbeat
+	if err := ob.ProcessEvent(heartbeat); err != nil || ob.LastUpdateID != 123461 {
+		t.Errorf("Invalid heartbeat! Expected update ID: %d, got: %d (%v)", 123461, ob.LastUpdateID, err)
+	}
+}
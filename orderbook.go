@@ -1,7 +1,9 @@
 package orderbook
 
 import (
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,117 +13,438 @@ type OrderBook struct {
 	LastUpdateID   int64
 	UpdatedAt      time.Time
 	PruneThreshold int
+	Instrument     Instrument
 
-	Asks *List
-	Bids *List
+	Asks Side
+	Bids Side
 
 	Loaded bool
+
+	// bridged is set once an event following the snapshot has been applied
+	bridged bool
+	newSide SideFactory
+	// ladderTick Ladder sides tick, instrument tick size is aligned to it
+	ladderTick int64
+
+	crossed     bool
+	crossPolicy CrossPolicy
+	onCrossed   func(bidPrice, askPrice int64)
+
+	checksum *ChecksumConfig
+
+	views bool
+	view  atomic.Value
+
+	subscriptions []*Subscription
+	deltas        []LevelDelta
+	pending       []pendingUpdate
+	// deferDelivery keeps updates pending until book lock is released
+	deferDelivery bool
+
+	bboListeners []func(bbo BBO)
+	bboCoalesce  bool
+	lastBBO      BBO
+}
+
+// Option configures OrderBook at New time
+type Option func(ob *OrderBook)
+
+// WithSides uses sides created by factory instead of skip lists
+func WithSides(factory SideFactory) Option {
+	return func(ob *OrderBook) {
+		ob.newSide = factory
+		ob.ladderTick = 0
+	}
+}
+
+// WithLadder uses tick indexed Ladder sides covering window ticks instead of skip lists. Instrument
+// TickSize is set to tick, or to least common multiple of both when instrument has other tick size,
+// so levels off ladder tick are rejected as invalid. Levels outside the window are counted, see Dropped.
+func WithLadder(tick int64, window int) Option {
+	withSides := WithSides(func(side int) Side {
+		return NewLadder(side, tick, window)
+	})
+
+	return func(ob *OrderBook) {
+		withSides(ob)
+		ob.ladderTick = tick
+	}
+}
+
+// alignTickSize aligns instrument tick size to ladder tick
+func (ob *OrderBook) alignTickSize() {
+	tick := ob.ladderTick
+	if tick <= 1 {
+		return
+	}
+
+	switch {
+	case ob.Instrument.TickSize <= 0:
+		ob.Instrument.TickSize = tick
+	case ob.Instrument.TickSize%tick != 0:
+		// Least common multiple
+		a, b := ob.Instrument.TickSize, tick
+		for b != 0 {
+			a, b = b, a%b
+		}
+		ob.Instrument.TickSize = ob.Instrument.TickSize / a * tick
+	}
+}
+
+// Dropped returns number of levels sides dropped on their own since sides were created (last snapshot or
+// Clear), e.g. Ladder levels outside its window. Levels removed by PruneThreshold are not counted.
+func (ob *OrderBook) Dropped() int64 {
+	var dropped int64
+	for _, side := range []Side{ob.Asks, ob.Bids} {
+		if reporter, ok := side.(DropReporter); ok {
+			dropped += reporter.Dropped()
+		}
+	}
+
+	return dropped
 }
 
 // New creates new struct instance of *OrderBook
-func New(symbol string, pruneThreshold int) *OrderBook {
-	return &OrderBook{
+func New(symbol string, pruneThreshold int, options ...Option) *OrderBook {
+	ob := &OrderBook{
 		Symbol:         symbol,
-		Asks:           &List{},
-		Bids:           &List{},
 		PruneThreshold: pruneThreshold,
+		Instrument:     DefaultInstrument,
 	}
+
+	for _, option := range options {
+		option(ob)
+	}
+	ob.alignTickSize()
+
+	ob.Asks = ob.createSide(OrderBookSideAsks)
+	ob.Bids = ob.createSide(OrderBookSideBids)
+	ob.publish()
+
+	return ob
 }
 
-// ProcessSnapshot processes depth snapshot
-func (ob *OrderBook) ProcessSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) {
-	ob.UpdatedAt = time.Now()
-	ob.LastUpdateID = snapshot.LastUpdateID
+// createSide creates empty side, skip list unless configured otherwise
+func (ob *OrderBook) createSide(side int) Side {
+	var s Side
+	if ob.newSide == nil {
+		s = NewSkipList(side)
+	} else {
+		s = ob.newSide(side)
+	}
 
-	// Asks
-	for _, ask := range snapshot.Asks {
-		ob.Asks.UpdateOrAddAsc(ask.Price, ask.Quantity)
+	if ob.views {
+		return newViewSide(s, side)
 	}
+	return s
+}
 
-	// Bids
-	for _, bid := range snapshot.Bids {
-		ob.Bids.UpdateOrAddDesc(bid.Price, bid.Quantity)
+// ProcessSnapshot replaces book with depth snapshot, buffered events must continue snapshot update ID.
+// Sides are built from scratch and swapped in, on invalid levels or sequence gap book is left as is,
+// a gap also marks it not loaded.
+func (ob *OrderBook) ProcessSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) error {
+	_, err := ob.loadSnapshot(snapshot, eventBuffer)
+	return err
+}
+
+// loadSnapshot processes snapshot and notifies listeners, swapped reports sides were replaced even if
+// error (checksum, crossed book) is returned
+func (ob *OrderBook) loadSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) (bool, error) {
+	before := ob.subscribedBBO()
+
+	swapped, err := ob.processSnapshot(snapshot, eventBuffer)
+	ob.publish()
+
+	if swapped {
+		ob.checkBBO(ob.LastUpdateID, ob.UpdatedAt)
+		ob.notify(before, true)
 	}
 
+	return swapped, err
+}
+
+// processSnapshot replaces book with depth snapshot, swapped reports sides were replaced
+func (ob *OrderBook) processSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) (bool, error) {
+	if invalid := ob.validateLevels(snapshot.Asks, snapshot.Bids); len(invalid) > 0 {
+		return false, &ErrInvalidEvent{
+			Symbol:        ob.Symbol,
+			FinalUpdateID: snapshot.LastUpdateID,
+			Levels:        invalid,
+		}
+	}
+
+	asks := ob.createSide(OrderBookSideAsks)
+	bids := ob.createSide(OrderBookSideBids)
+	now := time.Now()
+
+	if err := applyLevels(asks, bids, snapshot.Asks, snapshot.Bids, snapshot.LastUpdateID, now); err != nil {
+		return false, err
+	}
+
+	lastUpdateID := ob.LastUpdateID
+	bridged := ob.bridged
+
+	ob.LastUpdateID = snapshot.LastUpdateID
+	ob.bridged = false
+
 	// Process buffered events
 	for _, event := range eventBuffer {
-		if event.FinalUpdateID <= ob.LastUpdateID {
+		if event.FinalUpdateID <= ob.LastUpdateID && !ob.chained(event) {
 			// Ignore
 			continue
 		}
 
-		// Asks
-		for _, ask := range event.Asks {
-			ob.Asks.UpdateOrAddAsc(ask.Price, ask.Quantity)
+		err := ob.checkSequence(event)
+		if err != nil {
+			ob.Loaded = false
+		} else if invalid := ob.validateLevels(event.Asks, event.Bids); len(invalid) > 0 {
+			err = &ErrInvalidEvent{
+				Symbol:        ob.Symbol,
+				FinalUpdateID: event.FinalUpdateID,
+				Levels:        invalid,
+			}
+		} else {
+			err = applyLevels(asks, bids, event.Asks, event.Bids, event.FinalUpdateID, now)
 		}
 
-		// Bids
-		for _, bid := range event.Bids {
-			ob.Bids.UpdateOrAddDesc(bid.Price, bid.Quantity)
+		if err != nil {
+			ob.LastUpdateID = lastUpdateID
+			ob.bridged = bridged
+			return false, err
 		}
 
 		ob.LastUpdateID = event.FinalUpdateID
+		ob.bridged = true
 	}
 
+	ob.prune(asks)
+	ob.prune(bids)
+
+	// Swap sides
+	ob.Asks = asks
+	ob.Bids = bids
+	ob.UpdatedAt = now
+
 	// Mark as loaded
 	ob.Loaded = true
+
+	// Snapshot checksum covers snapshot levels only
+	if !ob.bridged {
+		if err := ob.verifyChecksum(snapshot.LastUpdateID, snapshot.Checksum, snapshot.HasChecksum); err != nil {
+			return true, err
+		}
+	}
+
+	return true, ob.checkCrossed(nil)
+}
+
+// validateLevels checks levels have positive price, non-negative size, unique price per side and fit instrument
+func (ob *OrderBook) validateLevels(asks []*Ask, bids []*Bid) []LevelError {
+	var invalid []LevelError
+
+	seen := make(map[int64]struct{}, len(asks))
+	for _, ask := range asks {
+		if reason := ob.levelReason(ask.Price, ask.Quantity, ask.Delete, seen); reason != "" {
+			invalid = append(invalid, LevelError{Side: OrderBookSideAsks, Price: ask.Price, Size: ask.Quantity, Reason: reason})
+		}
+	}
+
+	seen = make(map[int64]struct{}, len(bids))
+	for _, bid := range bids {
+		if reason := ob.levelReason(bid.Price, bid.Quantity, bid.Delete, seen); reason != "" {
+			invalid = append(invalid, LevelError{Side: OrderBookSideBids, Price: bid.Price, Size: bid.Quantity, Reason: reason})
+		}
+	}
+
+	return invalid
 }
 
-// ProcessEvent processes depth update event
+// levelReason returns why level is invalid, empty if valid. Removed levels are not checked against lot size.
+func (ob *OrderBook) levelReason(price, size int64, delete bool, seen map[int64]struct{}) string {
+	if price <= 0 {
+		return "price must be positive"
+	}
+
+	if size < 0 {
+		return "size must not be negative"
+	}
+
+	if _, ok := seen[price]; ok {
+		return "duplicate price"
+	}
+	seen[price] = struct{}{}
+
+	if delete {
+		size = 0
+	}
+
+	return ob.Instrument.levelReason(price, size)
+}
+
+// applyLevels applies validated levels to sides, levels are marked as set by updateID at time at
+func applyLevels(asks, bids Side, askLevels []*Ask, bidLevels []*Bid, updateID int64, at time.Time) error {
+	// Asks
+	for _, ask := range askLevels {
+		if err := setLevel(asks, ask.level(updateID, at), ask.Delete); err != nil {
+			return err
+		}
+	}
+
+	// Bids
+	for _, bid := range bidLevels {
+		if err := setLevel(bids, bid.level(updateID, at), bid.Delete); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setLevel updates side level, zero size or delete removes it. Removing missing level is not an error,
+// pruned books receive deletes for levels they no longer hold.
+func setLevel(side Side, level ListNode, delete bool) error {
+	if delete || level.Size == 0 {
+		if err := side.Remove(level.Price); err != nil && !errors.Is(err, ErrLevelNotFound) {
+			return err
+		}
+		return nil
+	}
+
+	if setter, ok := side.(LevelSetter); ok {
+		setter.SetLevel(level)
+	} else {
+		side.UpdateOrAdd(level.Price, level.Size)
+	}
+
+	return nil
+}
+
+// prune truncates side to PruneThreshold levels
+func (ob *OrderBook) prune(side Side) {
+	if ob.PruneThreshold > 0 && side.Size() > ob.PruneThreshold {
+		side.Prune(ob.PruneThreshold)
+	}
+}
+
+// checkSequence validates event continues book update IDs. First event after snapshot must
+// satisfy FirstUpdateID <= LastUpdateID+1 <= FinalUpdateID, following ones FirstUpdateID == LastUpdateID+1,
+// or PrevUpdateID == LastUpdateID when event has PrevUpdateID. Events with PrevUpdateID but no FirstUpdateID
+// (e.g. OKX) are chained from the snapshot. Events without either are only checked for ordering.
+func (ob *OrderBook) checkSequence(event *DepthEvent) error {
+	if event.PrevUpdateID != 0 && (ob.bridged || event.FirstUpdateID == 0) {
+		if event.PrevUpdateID == ob.LastUpdateID {
+			return nil
+		}
+
+		return &ErrSequenceGap{
+			Symbol:   ob.Symbol,
+			Expected: ob.LastUpdateID,
+			Actual:   event.PrevUpdateID,
+			Previous: true,
+		}
+	}
+
+	if event.FirstUpdateID == 0 {
+		return nil
+	}
+
+	expected := ob.LastUpdateID + 1
+
+	if event.FirstUpdateID == expected || (!ob.bridged && event.FirstUpdateID < expected) {
+		return nil
+	}
+
+	return &ErrSequenceGap{
+		Symbol:   ob.Symbol,
+		Expected: expected,
+		Actual:   event.FirstUpdateID,
+	}
+}
+
+// chained reports whether event continues book by PrevUpdateID
+func (ob *OrderBook) chained(event *DepthEvent) bool {
+	return event.PrevUpdateID != 0 && event.PrevUpdateID == ob.LastUpdateID && (ob.bridged || event.FirstUpdateID == 0)
+}
+
+// ProcessEvent processes depth update event. Event is applied all-or-nothing: on invalid levels
+// *ErrInvalidEvent is returned and book is left unchanged, on sequence gap book is marked not loaded
+// and *ErrSequenceGap returned, on checksum mismatch book is marked not loaded and *ErrChecksumMismatch
+// returned. Crossed or locked book is handled according to CrossPolicy.
 func (ob *OrderBook) ProcessEvent(event *DepthEvent) error {
+	before := ob.subscribedBBO()
+	lastUpdateID := ob.LastUpdateID
+
+	err := ob.processEvent(event)
+	ob.publish()
+
+	if ob.LastUpdateID != lastUpdateID {
+		ob.checkBBO(ob.LastUpdateID, ob.UpdatedAt)
+		ob.notify(before, false)
+	}
+
+	return err
+}
+
+// processEvent processes depth update event
+func (ob *OrderBook) processEvent(event *DepthEvent) error {
 	if !ob.Loaded {
 		return fmt.Errorf("no orderbook to update for symbol: %s", ob.Symbol)
 	}
 
-	// Validate and process event
-	if (event.FinalUpdateID <= ob.LastUpdateID) {
+	// Validate and process event, events chained by PrevUpdateID may repeat (heartbeat) or reset update ID
+	if event.FinalUpdateID <= ob.LastUpdateID && !ob.chained(event) {
 		return fmt.Errorf("invalid event(%s): %d <= %d new ID must be greater than previous ID", event.Symbol, event.FinalUpdateID, ob.LastUpdateID)
 	}
 
-	ob.UpdatedAt = time.Now()
-	ob.LastUpdateID = event.FinalUpdateID
+	if err := ob.checkSequence(event); err != nil {
+		ob.Loaded = false
+		return err
+	}
 
-	// Process Asks
-	for _, askUpdate := range event.Asks {
-		if askUpdate.Delete {
-			ob.Asks.Remove(askUpdate.Price)
-		} else {
-			ob.Asks.UpdateOrAddAsc(askUpdate.Price, askUpdate.Quantity)
+	if invalid := ob.validateLevels(event.Asks, event.Bids); len(invalid) > 0 {
+		return &ErrInvalidEvent{
+			Symbol:        ob.Symbol,
+			FinalUpdateID: event.FinalUpdateID,
+			Levels:        invalid,
 		}
 	}
 
-	// Process Bids
-	for _, bidUpdate := range event.Bids {
-		if bidUpdate.Delete {
-			ob.Bids.Remove(bidUpdate.Price)
-		} else {
-			ob.Bids.UpdateOrAddDesc(bidUpdate.Price, bidUpdate.Quantity)
-		}
+	now := time.Now()
+	if err := ob.applyEvent(event, now); err != nil {
+		// Side failed half way, book state is unknown
+		ob.Loaded = false
+		return err
 	}
+	ob.recordEvent(event)
+
+	ob.UpdatedAt = now
+	ob.LastUpdateID = event.FinalUpdateID
+	ob.bridged = true
 
 	// Prune lists
-	if ob.Asks.len > ob.PruneThreshold {
-		ob.Asks.Prune(ob.PruneThreshold)
-	}
+	ob.prune(ob.Asks)
+	ob.prune(ob.Bids)
 
-	if ob.Bids.len > ob.PruneThreshold {
-		ob.Bids.Prune(ob.PruneThreshold)
+	if err := ob.verifyChecksum(event.FinalUpdateID, event.Checksum, event.HasChecksum); err != nil {
+		return err
 	}
 
-	return nil
+	return ob.checkCrossed(event)
 }
 
 // Clear cache
 func (ob *OrderBook) Clear() {
+	before := ob.subscribedBBO()
+
 	ob.LastUpdateID = 0
-	ob.Asks = &List{
-		len:  0,
-		head: nil,
-	}
-	ob.Bids = &List{
-		len:  0,
-		head: nil,
-	}
+	ob.Asks = ob.createSide(OrderBookSideAsks)
+	ob.Bids = ob.createSide(OrderBookSideBids)
 	ob.UpdatedAt = time.Now()
 	ob.Loaded = false
+	ob.bridged = false
+	ob.crossed = false
+
+	ob.publish()
+	ob.checkBBO(ob.LastUpdateID, ob.UpdatedAt)
+	ob.notify(before, true)
 }
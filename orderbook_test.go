@@ -0,0 +1,390 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// sliceSide is a user defined Side backed by sorted slice
+type sliceSide struct {
+	desc   bool
+	levels []ListNode
+}
+
+func newSliceSide(side int) Side {
+	return &sliceSide{desc: side == OrderBookSideBids}
+}
+
+func (s *sliceSide) search(price int64) int {
+	return sort.Search(len(s.levels), func(i int) bool {
+		if s.desc {
+			return s.levels[i].Price <= price
+		}
+		return s.levels[i].Price >= price
+	})
+}
+
+func (s *sliceSide) UpdateOrAdd(price, size int64) {
+	i := s.search(price)
+	if i < len(s.levels) && s.levels[i].Price == price {
+		s.levels[i].Size = size
+		return
+	}
+
+	s.levels = append(s.levels, ListNode{})
+	copy(s.levels[i+1:], s.levels[i:])
+	s.levels[i] = ListNode{Price: price, Size: size}
+}
+
+func (s *sliceSide) Remove(price int64) error {
+	i := s.search(price)
+	if i == len(s.levels) || s.levels[i].Price != price {
+		return fmt.Errorf("Remove: %w", ErrLevelNotFound)
+	}
+
+	s.levels = append(s.levels[:i], s.levels[i+1:]...)
+	return nil
+}
+
+func (s *sliceSide) Front() (*ListNode, error) {
+	if len(s.levels) == 0 {
+		return nil, errors.New("Front: List is empty")
+	}
+	return &s.levels[0], nil
+}
+
+func (s *sliceSide) Each(fn func(node *ListNode) bool) {
+	for i := range s.levels {
+		if !fn(&s.levels[i]) {
+			return
+		}
+	}
+}
+
+func (s *sliceSide) Size() int {
+	return len(s.levels)
+}
+
+func (s *sliceSide) Prune(length int) {
+	if length > 0 && length < len(s.levels) {
+		s.levels = s.levels[:length]
+	}
+}
+
+// valueSide is a user defined Side stored by value, its dynamic type is not comparable
+type valueSide struct {
+	*sliceSide
+	tags []string
+}
+
+func newValueSide(side int) Side {
+	return valueSide{sliceSide: &sliceSide{desc: side == OrderBookSideBids}}
+}
+
+// testSnapshot order book snapshot, prices 48000 +/- levels, sizes 1.0
+func testSnapshot() *DepthSnapshot {
+	snapshot := &DepthSnapshot{LastUpdateID: 100}
+	for i := int64(0); i < 5; i++ {
+		snapshot.Asks = append(snapshot.Asks, &Ask{Price: 4800100000000 + i*100000000, Quantity: 1000000})
+		snapshot.Bids = append(snapshot.Bids, &Bid{Price: 4799900000000 - i*100000000, Quantity: 1000000})
+	}
+	return snapshot
+}
+
+// TestOrderBookSides checks all side implementations produce the same book
+func TestOrderBookSides(t *testing.T) {
+	factories := map[string]SideFactory{
+		"list": func(side int) Side {
+			return NewList(side)
+		},
+		"skiplist": func(side int) Side {
+			return NewSkipList(side)
+		},
+		"ladder": func(side int) Side {
+			return NewLadder(side, 100000000, 256)
+		},
+		"custom": newSliceSide,
+		"value":  newValueSide,
+	}
+
+	for name, factory := range factories {
+		ob := New("BTCUSDT", 4, WithSides(factory))
+		ob.ProcessSnapshot(testSnapshot(), nil)
+
+		err := ob.ProcessEvent(&DepthEvent{
+			Symbol:        "BTCUSDT",
+			FirstUpdateID: 101,
+			FinalUpdateID: 101,
+			Asks: []*Ask{
+				{Price: 4800100000000, Delete: true},
+				{Price: 4800000000000, Quantity: 500000},
+			},
+			Bids: []*Bid{
+				{Price: 4799800000000, Quantity: 2000000},
+			},
+		})
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+
+		if ob.Asks.Size() != 4 || ob.Bids.Size() != 4 {
+			t.Errorf("%s: Invalid level count! Expected: %d/%d, got: %d/%d", name, 4, 4, ob.Asks.Size(), ob.Bids.Size())
+		}
+
+		price, _ := ob.GetMarketPrice()
+		if !price.Equal(decimal.RequireFromString("47999.5")) {
+			t.Errorf("%s: Invalid market price! Expected: %s, got: %s", name, "47999.5", price)
+		}
+
+		// Buy 1.5 BTC worth of quote: 0.5 @ 48000, 1 @ 48002
+		amount, err := ob.OrderBookAskConversion(decimal.NewFromInt(24000 + 48002))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		} else if !amount.Equal(decimal.RequireFromString("1.5")) {
+			t.Errorf("%s: Invalid conversion! Expected: %s, got: %s", name, "1.5", amount)
+		}
+
+		// Sell 3 BTC: 1 @ 47999, 2 @ 47998
+		amount, err = ob.OrderBookBidConversion(decimal.NewFromInt(3))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		} else if !amount.Equal(decimal.NewFromInt(47999 + 2*47998)) {
+			t.Errorf("%s: Invalid conversion! Expected: %d, got: %s", name, 47999+2*47998, amount)
+		}
+
+		ob.Clear()
+		if ob.Asks.Size() != 0 || ob.Bids.Size() != 0 {
+			t.Errorf("%s: Expected empty book after Clear", name)
+		}
+	}
+}
+
+// TestLevelMetadata checks levels keep order count and last update
+func TestLevelMetadata(t *testing.T) {
+	for _, views := range []bool{false, true} {
+		var options []Option
+		if views {
+			options = append(options, WithViews())
+		}
+
+		ob := New("BTCUSDT", 10, options...)
+		ob.ProcessSnapshot(testSnapshot(), []*DepthEvent{
+			{FirstUpdateID: 100, FinalUpdateID: 101, Bids: []*Bid{{Price: 4799800000000, Quantity: 3000000, Count: 2}}},
+		})
+		loadedAt := ob.UpdatedAt
+
+		err := ob.ProcessEvent(&DepthEvent{
+			FirstUpdateID: 102,
+			FinalUpdateID: 102,
+			Bids:          []*Bid{{Price: 4799900000000, Quantity: 2000000, Count: 5}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var bids SideReader = ob.Bids
+		if views {
+			bids = ob.View().Bids
+		}
+
+		var levels []ListNode
+		bids.Each(func(node *ListNode) bool {
+			levels = append(levels, *node)
+			return true
+		})
+
+		if len(levels) != 5 {
+			t.Fatalf("Invalid level count! Expected: %d, got: %d", 5, len(levels))
+		}
+
+		if levels[0].Count != 5 || levels[0].UpdateID != 102 || !levels[0].UpdatedAt.Equal(ob.UpdatedAt) {
+			t.Errorf("Invalid event level! Got: %+v", levels[0])
+		}
+
+		if levels[1].Count != 2 || levels[1].UpdateID != 101 || !levels[1].UpdatedAt.Equal(loadedAt) {
+			t.Errorf("Invalid buffered event level! Got: %+v", levels[1])
+		}
+
+		if levels[2].Count != 0 || levels[2].UpdateID != 100 || !levels[2].UpdatedAt.Equal(loadedAt) {
+			t.Errorf("Invalid snapshot level! Got: %+v", levels[2])
+		}
+
+		front, _ := bids.Front()
+		if front.UpdateID != 102 {
+			t.Errorf("Invalid front update ID! Expected: %d, got: %d", 102, front.UpdateID)
+		}
+	}
+}
+
+// TestProcessEventSequenceGap checks update ID continuity
+func TestProcessEventSequenceGap(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	// First event may start before snapshot
+	err := ob.ProcessEvent(&DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 95, FinalUpdateID: 105})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Following events must continue previous final update ID
+	err = ob.ProcessEvent(&DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 106, FinalUpdateID: 110})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = ob.ProcessEvent(&DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 112, FinalUpdateID: 115})
+
+	var gap *ErrSequenceGap
+	if !errors.As(err, &gap) {
+		t.Errorf("Expected sequence gap, got: %v", err)
+		return
+	}
+
+	if gap.Expected != 111 || gap.Actual != 112 {
+		t.Errorf("Invalid sequence gap! Expected: %d/%d, got: %d/%d", 111, 112, gap.Expected, gap.Actual)
+	}
+
+	if ob.Loaded || ob.LastUpdateID != 110 {
+		t.Errorf("Expected book marked not loaded at update ID %d, got: %d", 110, ob.LastUpdateID)
+	}
+}
+
+// TestProcessSnapshotSequenceGap checks first buffered event bridges snapshot
+func TestProcessSnapshotSequenceGap(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+
+	// Snapshot older than buffered events
+	err := ob.ProcessSnapshot(testSnapshot(), []*DepthEvent{
+		{Symbol: "BTCUSDT", FirstUpdateID: 102, FinalUpdateID: 103},
+	})
+
+	var gap *ErrSequenceGap
+	if !errors.As(err, &gap) || ob.Loaded {
+		t.Errorf("Expected sequence gap, got: %v", err)
+	}
+
+	// Stale events are skipped, first applied event bridges snapshot
+	ob.Clear()
+	err = ob.ProcessSnapshot(testSnapshot(), []*DepthEvent{
+		{Symbol: "BTCUSDT", FirstUpdateID: 90, FinalUpdateID: 95},
+		{Symbol: "BTCUSDT", FirstUpdateID: 96, FinalUpdateID: 101},
+		{Symbol: "BTCUSDT", FirstUpdateID: 102, FinalUpdateID: 104},
+	})
+
+	if err != nil || !ob.Loaded || ob.LastUpdateID != 104 {
+		t.Errorf("Expected loaded book at update ID %d, got: %d (%v)", 104, ob.LastUpdateID, err)
+	}
+}
+
+// TestProcessSnapshotReplace checks resync replaces previous book
+func TestProcessSnapshotReplace(t *testing.T) {
+	ob := New("BTCUSDT", 3)
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	// Stale level not in next snapshot
+	ob.ProcessEvent(&DepthEvent{
+		Symbol:        "BTCUSDT",
+		FirstUpdateID: 101,
+		FinalUpdateID: 101,
+		Asks:          []*Ask{{Price: 4800000000000, Quantity: 1000000}},
+	})
+
+	snapshot := testSnapshot()
+	snapshot.LastUpdateID = 200
+	snapshot.Asks = append(snapshot.Asks, &Ask{Price: 4800050000000, Quantity: 0})
+	snapshot.Bids[0].Quantity = 0
+
+	err := ob.ProcessSnapshot(snapshot, []*DepthEvent{
+		{
+			Symbol:        "BTCUSDT",
+			FirstUpdateID: 201,
+			FinalUpdateID: 201,
+			Asks:          []*Ask{{Price: 4800100000000, Delete: true}},
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ask, _ := ob.Asks.Front()
+	if ask.Price != 4800200000000 {
+		t.Errorf("Invalid first ask! Expected: %d, got: %d", 4800200000000, ask.Price)
+	}
+
+	bid, _ := ob.Bids.Front()
+	if bid.Price != 4799800000000 {
+		t.Errorf("Invalid first bid! Expected: %d, got: %d", 4799800000000, bid.Price)
+	}
+
+	if ob.Asks.Size() != 3 || ob.Bids.Size() != 3 {
+		t.Errorf("Invalid level count! Expected: %d/%d, got: %d/%d", 3, 3, ob.Asks.Size(), ob.Bids.Size())
+	}
+
+	// Gap keeps previous book
+	err = ob.ProcessSnapshot(testSnapshot(), []*DepthEvent{{Symbol: "BTCUSDT", FirstUpdateID: 150, FinalUpdateID: 151}})
+	if err == nil || ob.Loaded || ob.LastUpdateID != 201 {
+		t.Errorf("Expected sequence gap at update ID %d, got: %d (%v)", 201, ob.LastUpdateID, err)
+	}
+
+	ask, _ = ob.Asks.Front()
+	if ask.Price != 4800200000000 {
+		t.Errorf("Invalid first ask! Expected: %d, got: %d", 4800200000000, ask.Price)
+	}
+}
+
+// TestProcessEventInvalidLevels checks invalid event leaves book unchanged
+func TestProcessEventInvalidLevels(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	err := ob.ProcessEvent(&DepthEvent{
+		Symbol:        "BTCUSDT",
+		FirstUpdateID: 101,
+		FinalUpdateID: 101,
+		Asks: []*Ask{
+			{Price: 4800100000000, Delete: true},
+			{Price: 4800000000000, Quantity: 1000000},
+			{Price: 4800000000000, Quantity: 2000000},
+		},
+		Bids: []*Bid{
+			{Price: 4799900000000, Quantity: 3000000},
+			{Price: 0, Quantity: 1000000},
+			{Price: 4799800000000, Quantity: -1},
+		},
+	})
+
+	var invalid *ErrInvalidEvent
+	if !errors.As(err, &invalid) {
+		t.Errorf("Expected invalid event error, got: %v", err)
+		return
+	}
+
+	if len(invalid.Levels) != 3 {
+		t.Errorf("Invalid level error count! Expected: %d, got: %d (%s)", 3, len(invalid.Levels), err)
+	}
+
+	// Book unchanged
+	ask, _ := ob.Asks.Front()
+	bid, _ := ob.Bids.Front()
+	if !ob.Loaded || ob.LastUpdateID != 100 || ask.Price != 4800100000000 || bid.Size != 1000000 {
+		t.Errorf("Expected unchanged book")
+	}
+
+	// Deleting missing level is not an error
+	err = ob.ProcessEvent(&DepthEvent{
+		Symbol:        "BTCUSDT",
+		FirstUpdateID: 101,
+		FinalUpdateID: 101,
+		Asks:          []*Ask{{Price: 4900000000000, Delete: true}},
+	})
+	if err != nil || ob.LastUpdateID != 101 {
+		t.Errorf("Expected applied event, got: %v", err)
+	}
+}
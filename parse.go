@@ -0,0 +1,374 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RoundingMode defines how strict parsers handle digits beyond target precision
+type RoundingMode int
+
+const (
+	// RoundingStrict rejects non-zero digits beyond precision with ErrExcessPrecision
+	RoundingStrict RoundingMode = iota
+	// RoundingTruncate drops excess digits (rounds toward zero)
+	RoundingTruncate
+	// RoundingHalfUp rounds half away from zero
+	RoundingHalfUp
+	// RoundingHalfEven rounds half to even (banker's rounding)
+	RoundingHalfEven
+)
+
+var (
+	// ErrInvalidNumber is returned for malformed number strings
+	ErrInvalidNumber = errors.New("invalid number")
+	// ErrExcessPrecision is returned by RoundingStrict when value has more decimals than allowed
+	ErrExcessPrecision = errors.New("excess precision")
+	// ErrOverflow is returned when value does not fit target integer type
+	ErrOverflow = errors.New("overflow")
+)
+
+// maxExponent limits exponent notation, larger exponents always overflow or round to zero
+const maxExponent = 9999
+
+// ParseDecimal parses integer, decimal or exponent notation string (e.g. "-5", "+0.25", "1e-7") to integer
+// units of 10^exp, excess precision is handled according to mode
+func ParseDecimal(value string, exp int32, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-exp), mode)
+}
+
+// ParseSatoshi parses decimal string to int64 (satoshi), see ParseDecimal
+func ParseSatoshi(value string, mode RoundingMode) (int64, error) {
+	return parseNumber(value, -PriceDecimalExp, mode)
+}
+
+// ParseSize parses decimal string to int64 (size), see ParseDecimal
+func ParseSize(value string, mode RoundingMode) (int64, error) {
+	return parseNumber(value, -SizeDecimalExp, mode)
+}
+
+// ParsePercentage parses decimal string to int32 (percentage), see ParseDecimal
+func ParsePercentage(value string, mode RoundingMode) (int32, error) {
+	return toInt32(parseNumber(value, -PercentageDecimalExp, mode))
+}
+
+// ParseDecimalBytes parses number bytes without allocating, see ParseDecimal
+func ParseDecimalBytes(value []byte, exp int32, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-exp), mode)
+}
+
+// ParseSatoshiBytes parses number bytes to int64 (satoshi) without allocating, see ParseDecimal
+func ParseSatoshiBytes(value []byte, mode RoundingMode) (int64, error) {
+	return parseNumber(value, -PriceDecimalExp, mode)
+}
+
+// ParseSizeBytes parses number bytes to int64 (size) without allocating, see ParseDecimal
+func ParseSizeBytes(value []byte, mode RoundingMode) (int64, error) {
+	return parseNumber(value, -SizeDecimalExp, mode)
+}
+
+// ParsePercentageBytes parses number bytes to int32 (percentage) without allocating, see ParseDecimal
+func ParsePercentageBytes(value []byte, mode RoundingMode) (int32, error) {
+	return toInt32(parseNumber(value, -PercentageDecimalExp, mode))
+}
+
+// ParsePriceStrict parses decimal string to price units, see ParseDecimal
+func (i Instrument) ParsePriceStrict(value string, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-i.PriceExp), mode)
+}
+
+// ParseSizeStrict parses decimal string to size units, see ParseDecimal
+func (i Instrument) ParseSizeStrict(value string, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-i.SizeExp), mode)
+}
+
+// ParsePriceBytes parses number bytes to price units without allocating, see ParseDecimal
+func (i Instrument) ParsePriceBytes(value []byte, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-i.PriceExp), mode)
+}
+
+// ParseSizeBytes parses number bytes to size units without allocating, see ParseDecimal
+func (i Instrument) ParseSizeBytes(value []byte, mode RoundingMode) (int64, error) {
+	return parseNumber(value, int(-i.SizeExp), mode)
+}
+
+// toInt32 narrows parsed value
+func toInt32(value int64, err error) (int32, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	if value > math.MaxInt32 || value < math.MinInt32 {
+		return 0, fmt.Errorf("parse: %w", ErrOverflow)
+	}
+
+	return int32(value), nil
+}
+
+// parseNumber parses plain decimal numbers in single pass, exponent notation falls back to parseDecimal
+func parseNumber[T string | []byte](value T, decimals int, mode RoundingMode) (int64, error) {
+	i := 0
+	negative := false
+	if i < len(value) && (value[i] == '+' || value[i] == '-') {
+		negative = value[i] == '-'
+		i++
+	}
+
+	limit := uint64(math.MaxInt64)
+	if negative {
+		limit++
+	}
+
+	var result uint64
+	var roundDigit byte
+	sticky := false
+	digits := 0
+	fraction := -1
+
+	for ; i < len(value); i++ {
+		c := value[i]
+
+		if c == '.' && fraction < 0 {
+			fraction = 0
+			continue
+		}
+
+		if c < '0' || c > '9' {
+			if (c == 'e' || c == 'E') && digits > 0 {
+				return parseDecimal(value, decimals, mode)
+			}
+			return 0, fmt.Errorf("parse %q: %w", value, ErrInvalidNumber)
+		}
+		digits++
+
+		if fraction >= 0 {
+			fraction++
+			if fraction > decimals {
+				if fraction == decimals+1 {
+					roundDigit = c - '0'
+				} else {
+					sticky = sticky || c != '0'
+				}
+				continue
+			}
+		}
+
+		digit := uint64(c - '0')
+		if result > (limit-digit)/10 {
+			// Negative exponent may scale long mantissa back into range
+			if hasExponent(value[i:]) {
+				return parseDecimal(value, decimals, mode)
+			}
+			return 0, fmt.Errorf("parse %q: %w", value, ErrOverflow)
+		}
+		result = result*10 + digit
+	}
+
+	if digits == 0 {
+		return 0, fmt.Errorf("parse %q: %w", value, ErrInvalidNumber)
+	}
+
+	// Pad missing fraction digits
+	if fraction < 0 {
+		fraction = 0
+	}
+
+	for ; fraction < decimals; fraction++ {
+		if result > limit/10 {
+			return 0, fmt.Errorf("parse %q: %w", value, ErrOverflow)
+		}
+		result *= 10
+	}
+
+	return round(value, result, limit, negative, roundDigit, sticky, mode)
+}
+
+// hasExponent reports whether value contains exponent marker
+func hasExponent[T string | []byte](value T) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] == 'e' || value[i] == 'E' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// round applies rounding mode to parsed magnitude, roundDigit is first excess digit and sticky is set
+// when any following excess digit is non-zero
+func round[T string | []byte](value T, result, limit uint64, negative bool, roundDigit byte, sticky bool, mode RoundingMode) (int64, error) {
+	if roundDigit != 0 || sticky {
+		up := false
+
+		switch mode {
+		case RoundingStrict:
+			return 0, fmt.Errorf("parse %q: %w", value, ErrExcessPrecision)
+		case RoundingHalfUp:
+			up = roundDigit >= 5
+		case RoundingHalfEven:
+			up = roundDigit > 5 || (roundDigit == 5 && (sticky || result%2 == 1))
+		}
+
+		if up {
+			if result == limit {
+				return 0, fmt.Errorf("parse %q: %w", value, ErrOverflow)
+			}
+			result++
+		}
+	}
+
+	if negative {
+		return int64(-result), nil
+	}
+
+	return int64(result), nil
+}
+
+// decimalNumber is validated number string layout
+type decimalNumber struct {
+	negative bool
+	// digits mantissa digit count, integers digits before decimal point
+	digits   int
+	integers int
+	exponent int
+}
+
+// scanDecimal validates number and returns its layout, sign, mantissa and exponent are located in single pass
+func scanDecimal[T string | []byte](value T) (decimalNumber, bool) {
+	var n decimalNumber
+
+	i := 0
+	if i < len(value) && (value[i] == '+' || value[i] == '-') {
+		n.negative = value[i] == '-'
+		i++
+	}
+
+	dot := false
+	for ; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			n.digits++
+			if !dot {
+				n.integers++
+			}
+			continue
+		}
+
+		if c == '.' && !dot {
+			dot = true
+			continue
+		}
+
+		break
+	}
+
+	if n.digits == 0 {
+		return n, false
+	}
+
+	if i == len(value) {
+		return n, true
+	}
+
+	// Exponent
+	if value[i] != 'e' && value[i] != 'E' {
+		return n, false
+	}
+	i++
+
+	negative := false
+	if i < len(value) && (value[i] == '+' || value[i] == '-') {
+		negative = value[i] == '-'
+		i++
+	}
+
+	if i == len(value) {
+		return n, false
+	}
+
+	for ; i < len(value); i++ {
+		c := value[i]
+		if c < '0' || c > '9' {
+			return n, false
+		}
+
+		n.exponent = n.exponent*10 + int(c-'0')
+		if n.exponent > maxExponent {
+			return n, false
+		}
+	}
+
+	if negative {
+		n.exponent = -n.exponent
+	}
+
+	return n, true
+}
+
+// parseDecimal parses number string scaled by 10^decimals, supports exponent notation
+func parseDecimal[T string | []byte](value T, decimals int, mode RoundingMode) (int64, error) {
+	n, ok := scanDecimal(value)
+	if !ok {
+		return 0, fmt.Errorf("parse %q: %w", value, ErrInvalidNumber)
+	}
+
+	// Mantissa digits kept in result, following digits are excess precision
+	keep := n.integers + n.exponent + decimals
+
+	limit := uint64(math.MaxInt64)
+	if n.negative {
+		limit++
+	}
+
+	var result uint64
+	var roundDigit byte
+	sticky := false
+	overflow := false
+	index := 0
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == 'e' || c == 'E' {
+			break
+		}
+
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		switch {
+		case index < keep:
+			digit := uint64(c - '0')
+			if result > (limit-digit)/10 {
+				overflow = true
+			}
+			result = result*10 + digit
+		case index == keep:
+			roundDigit = c - '0'
+		default:
+			sticky = sticky || c != '0'
+		}
+		index++
+
+		if overflow {
+			return 0, fmt.Errorf("parse %q: %w", value, ErrOverflow)
+		}
+	}
+
+	// Value is below 10^-decimals, first dropped digit is an implicit zero
+	if keep < 0 {
+		sticky = sticky || roundDigit != 0
+		roundDigit = 0
+	}
+
+	// Pad missing digits
+	for ; index < keep && result != 0; index++ {
+		if result > limit/10 {
+			return 0, fmt.Errorf("parse %q: %w", value, ErrOverflow)
+		}
+		result *= 10
+	}
+
+	return round(value, result, limit, n.negative, roundDigit, sticky, mode)
+}
@@ -0,0 +1,170 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// TestParseSatoshi checks strict parsing cases
+func TestParseSatoshi(t *testing.T) {
+	cases := []struct {
+		value    string
+		mode     RoundingMode
+		expected int64
+		err      error
+	}{
+		{"5", RoundingStrict, 500000000, nil},
+		{"+48.000012", RoundingStrict, 4800001200, nil},
+		{"-0.01633102", RoundingStrict, -1633102, nil},
+		{".5", RoundingStrict, 50000000, nil},
+		{"5.", RoundingStrict, 500000000, nil},
+		{"1e-7", RoundingStrict, 10, nil},
+		{"1.5E+2", RoundingStrict, 15000000000, nil},
+		{"0.123456780000", RoundingStrict, 12345678, nil},
+		{"0.123456789", RoundingStrict, 0, ErrExcessPrecision},
+		{"0.123456789", RoundingTruncate, 12345678, nil},
+		{"0.123456785", RoundingHalfUp, 12345679, nil},
+		{"-0.123456785", RoundingHalfUp, -12345679, nil},
+		{"0.123456785", RoundingHalfEven, 12345678, nil},
+		{"0.1234567851", RoundingHalfEven, 12345679, nil},
+		{"0.000000005", RoundingHalfUp, 1, nil},
+		{"1e-10", RoundingHalfUp, 0, nil},
+		{"92233720368.54775807", RoundingStrict, 9223372036854775807, nil},
+		{"-92233720368.54775808", RoundingStrict, -9223372036854775808, nil},
+		{"92233720368.54775808", RoundingStrict, 0, ErrOverflow},
+		{"92233720368.547758075", RoundingHalfUp, 0, ErrOverflow},
+		{"1e12", RoundingStrict, 0, ErrOverflow},
+		{"10000000000000000000E-10", RoundingStrict, 100000000000000000, nil},
+		{"123456789012345678901e-15", RoundingTruncate, 12345678901234, nil},
+		{"123456789012345678901e-15", RoundingStrict, 0, ErrExcessPrecision},
+		{"100000000000000000000e-5", RoundingStrict, 0, ErrOverflow},
+		{"0e99", RoundingStrict, 0, nil},
+		{"", RoundingStrict, 0, ErrInvalidNumber},
+		{"-", RoundingStrict, 0, ErrInvalidNumber},
+		{"1.2.3", RoundingStrict, 0, ErrInvalidNumber},
+		{"1e", RoundingStrict, 0, ErrInvalidNumber},
+		{"0x10", RoundingStrict, 0, ErrInvalidNumber},
+		{" 1", RoundingStrict, 0, ErrInvalidNumber},
+	}
+
+	for _, c := range cases {
+		result, err := ParseSatoshi(c.value, c.mode)
+		if !errors.Is(err, c.err) || (c.err == nil && err != nil) {
+			t.Errorf("Invalid error for %q! Expected: %v, got: %v", c.value, c.err, err)
+			continue
+		}
+
+		if result != c.expected {
+			t.Errorf("Invalid result for %q! Expected: %d, got: %d", c.value, c.expected, result)
+		}
+	}
+}
+
+// TestParsePercentage checks int32 overflow
+func TestParsePercentage(t *testing.T) {
+	if result, err := ParsePercentage("12.34", RoundingStrict); err != nil || result != 123400 {
+		t.Errorf("Invalid percentage! Expected: %d, got: %d (%v)", 123400, result, err)
+	}
+
+	if _, err := ParsePercentage("214749", RoundingStrict); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected overflow, got: %v", err)
+	}
+}
+
+// FuzzParseDecimal compares parsed values against shopspring/decimal
+func FuzzParseDecimal(f *testing.F) {
+	for _, seed := range []string{"5", "-48.000012", "0.123456789", "1e-7", "-1.5E+2", ".5", "92233720368.54775807"} {
+		f.Add(seed, uint8(RoundingHalfEven))
+	}
+
+	f.Fuzz(func(t *testing.T, value string, mode uint8) {
+		roundingMode := RoundingMode(mode % 4)
+
+		result, err := ParseSatoshi(value, roundingMode)
+
+		// Byte, single pass and exponent parsers agree
+		bytesResult, bytesErr := ParseSatoshiBytes([]byte(value), roundingMode)
+		slowResult, slowErr := parseDecimal(value, -PriceDecimalExp, roundingMode)
+		if bytesResult != result || slowResult != result || (bytesErr == nil) != (err == nil) || (slowErr == nil) != (err == nil) {
+			t.Fatalf("Parsers disagree on %q! Got: %d (%v), %d (%v), %d (%v)", value, result, err, bytesResult, bytesErr, slowResult, slowErr)
+		}
+
+		if err != nil {
+			return
+		}
+
+		d, derr := decimal.NewFromString(value)
+		if derr != nil {
+			t.Fatalf("Parsed %q rejected by decimal: %v", value, derr)
+		}
+
+		shifted := d.Shift(-PriceDecimalExp)
+
+		var expected decimal.Decimal
+		switch roundingMode {
+		case RoundingStrict:
+			expected = shifted
+		case RoundingTruncate:
+			expected = shifted.Truncate(0)
+		case RoundingHalfUp:
+			expected = shifted.Round(0)
+		case RoundingHalfEven:
+			expected = shifted.RoundBank(0)
+		}
+
+		if !expected.Equal(decimal.NewFromInt(result)) {
+			t.Fatalf("Invalid result for %q (mode %d)! Expected: %s, got: %d", value, roundingMode, expected, result)
+		}
+
+		// Round trip
+		again, err := ParseSatoshi(SatoshiToDecimalString(result), RoundingStrict)
+		if err != nil || again != result {
+			t.Fatalf("Invalid round trip for %d! Got: %d (%v)", result, again, err)
+		}
+	})
+}
+
+// TestParseBytesAllocs checks byte parsers do not allocate
+func TestParseBytesAllocs(t *testing.T) {
+	values := [][]byte{[]byte("48000.12345678"), []byte("-0.000012"), []byte("1.5e-3")}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, value := range values {
+			ParseSatoshiBytes(value, RoundingHalfEven)
+			ParseSizeBytes(value, RoundingTruncate)
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("Invalid allocation count! Expected: %d, got: %f", 0, allocs)
+	}
+
+	if result, err := ParseSizeBytes([]byte("1.5e-3"), RoundingStrict); err != nil || result != 1500 {
+		t.Errorf("Invalid size! Expected: %d, got: %d (%v)", 1500, result, err)
+	}
+}
+
+func BenchmarkDecimalStringToSatoshi(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DecimalStringToSatoshi("48000.12345678")
+	}
+}
+
+func BenchmarkParseSatoshi(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseSatoshi("48000.12345678", RoundingStrict)
+	}
+}
+
+func BenchmarkParseSatoshiBytes(b *testing.B) {
+	value := []byte("48000.12345678")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseSatoshiBytes(value, RoundingStrict)
+	}
+}
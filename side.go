@@ -0,0 +1,58 @@
+package orderbook
+
+// Side is a price level store used for OrderBook Asks and Bids.
+// Implementations own level ordering: asks ascending, bids descending, so OrderBook
+// never has to pick the direction. Front, Each and Size must not modify the side so they can be
+// called concurrently by ConcurrentOrderBook readers. List, SkipList and Ladder implement Side.
+type Side interface {
+	SideReader
+
+	// UpdateOrAdd sets level size, adding level if it does not exist
+	UpdateOrAdd(price, size int64)
+	// Remove deletes level, error wrapping ErrLevelNotFound if it does not exist.
+	// OrderBook relies on Remove not failing otherwise to apply events atomically.
+	Remove(price int64) error
+	// Prune keeps first length levels
+	Prune(length int)
+}
+
+// LevelSetter is implemented by sides keeping level metadata (order count, last update), OrderBook sets levels through
+// SetLevel when side implements it and through UpdateOrAdd otherwise. List and SkipList implement LevelSetter,
+// Ladder keeps size only.
+type LevelSetter interface {
+	// SetLevel sets level size and metadata, adding level if it does not exist
+	SetLevel(level ListNode)
+}
+
+// DropReporter is implemented by sides dropping levels on their own, e.g. Ladder levels outside its window
+type DropReporter interface {
+	// Dropped returns number of dropped levels and ignored level updates
+	Dropped() int64
+}
+
+// SideReader is read only part of Side
+type SideReader interface {
+	// Front returns best level, error if side is empty
+	Front() (*ListNode, error)
+	// Each calls fn for every level in priority order until fn returns false.
+	// Nodes may be reused between calls and must not be retained.
+	Each(fn func(node *ListNode) bool)
+	// Size returns level count
+	Size() int
+}
+
+// SideFactory creates empty side for OrderBookSideBids or OrderBookSideAsks
+type SideFactory func(side int) Side
+
+var (
+	_ Side = (*List)(nil)
+	_ Side = (*SkipList)(nil)
+	_ Side = (*Ladder)(nil)
+
+	_ LevelSetter = (*List)(nil)
+	_ LevelSetter = (*SkipList)(nil)
+	_ LevelSetter = (*viewSide)(nil)
+
+	_ DropReporter = (*Ladder)(nil)
+	_ DropReporter = (*viewSide)(nil)
+)
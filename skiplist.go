@@ -0,0 +1,255 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// skipListMaxLevel max node height, with p = 1/4 enough for 4^16 levels
+	skipListMaxLevel = 16
+	// skipListSeed initial random level generator state
+	skipListSeed = 0x9e3779b97f4a7c15
+)
+
+// SkipList struct is a price ordered skip list with O(log n) updates and O(1) front access.
+// Level 0 is linked through ListNode.next so nodes can be iterated the same way as List nodes.
+type SkipList struct {
+	len   int
+	level int
+	desc  bool
+	seed  uint64
+
+	// Sentinel node, head.next is the first node
+	head ListNode
+}
+
+// NewSkipList creates new skip list ordered for side (OrderBookSideBids descending, OrderBookSideAsks ascending)
+func NewSkipList(side int) *SkipList {
+	l := &SkipList{
+		desc: side == OrderBookSideBids,
+	}
+	l.init()
+
+	return l
+}
+
+// init prepares sentinel node, zero value SkipList is ascending
+func (l *SkipList) init() {
+	if l.head.skip != nil {
+		return
+	}
+
+	l.head.skip = make([]*ListNode, skipListMaxLevel-1)
+	l.level = 1
+	l.seed = skipListSeed
+}
+
+// before reports whether price a is ordered before price b
+func (l *SkipList) before(a, b int64) bool {
+	if l.desc {
+		return a > b
+	}
+	return a < b
+}
+
+// search fills update with the last node before price on every level and returns the first node not before price
+func (l *SkipList) search(price int64, update *[skipListMaxLevel]*ListNode) *ListNode {
+	current := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for next := current.forward(i); next != nil && l.before(next.Price, price); next = current.forward(i) {
+			current = next
+		}
+		update[i] = current
+	}
+
+	return current.next
+}
+
+// randomLevel returns height for a new node (xorshift64, p = 1/4)
+func (l *SkipList) randomLevel() int {
+	l.seed ^= l.seed << 13
+	l.seed ^= l.seed >> 7
+	l.seed ^= l.seed << 17
+
+	level := 1
+	for x := l.seed; level < skipListMaxLevel && x&3 == 0; x >>= 2 {
+		level++
+	}
+
+	return level
+}
+
+// shrink lowers list level while top levels are empty
+func (l *SkipList) shrink() {
+	for l.level > 1 && l.head.forward(l.level-1) == nil {
+		l.level--
+	}
+}
+
+// UpdateOrAdd node
+func (l *SkipList) UpdateOrAdd(price, size int64) {
+	l.SetLevel(ListNode{Price: price, Size: size})
+}
+
+// SetLevel updates or adds node
+func (l *SkipList) SetLevel(level ListNode) {
+	l.init()
+
+	var update [skipListMaxLevel]*ListNode
+
+	price := level.Price
+	current := l.search(price, &update)
+	if current != nil && current.Price == price {
+		// Found node! Update current node.
+		current.set(level)
+		return
+	}
+
+	height := l.randomLevel()
+	if height > l.level {
+		for i := l.level; i < height; i++ {
+			update[i] = &l.head
+		}
+		l.level = height
+	}
+
+	node := &ListNode{
+		Price: price,
+	}
+	node.set(level)
+	if height > 1 {
+		node.skip = make([]*ListNode, height-1)
+	}
+
+	// Link node on every level
+	for i := 0; i < height; i++ {
+		node.setForward(i, update[i].forward(i))
+		update[i].setForward(i, node)
+	}
+
+	l.len++
+}
+
+// Remove node
+func (l *SkipList) Remove(price int64) error {
+	if l.head.next == nil {
+		return fmt.Errorf("Remove: List is empty, %w", ErrLevelNotFound)
+	}
+
+	var update [skipListMaxLevel]*ListNode
+
+	current := l.search(price, &update)
+	if current == nil || current.Price != price {
+		return fmt.Errorf("Remove: %w", ErrLevelNotFound)
+	}
+
+	// Unlink node on every level
+	for i := 0; i <= len(current.skip); i++ {
+		update[i].setForward(i, current.forward(i))
+	}
+
+	l.shrink()
+	l.len--
+
+	return nil
+}
+
+// RemoveFront node
+func (l *SkipList) RemoveFront() error {
+	node := l.head.next
+	if node == nil {
+		return errors.New("RemoveFront: List is empty")
+	}
+
+	// First node follows the sentinel on all of its levels
+	for i := 0; i <= len(node.skip); i++ {
+		l.head.setForward(i, node.forward(i))
+	}
+
+	l.shrink()
+	l.len--
+
+	return nil
+}
+
+// RemoveBack node
+func (l *SkipList) RemoveBack() error {
+	last, err := l.Last()
+	if err != nil {
+		return errors.New("RemoveBack: List is empty")
+	}
+
+	return l.Remove(last.Price)
+}
+
+// Prune nodes, keeps first length nodes
+func (l *SkipList) Prune(length int) {
+	if length < 1 || length >= l.len {
+		return
+	}
+
+	// Last kept node on every level
+	var last [skipListMaxLevel]*ListNode
+	for i := 0; i < l.level; i++ {
+		last[i] = &l.head
+	}
+
+	current := l.head.next
+	for i := 1; ; i++ {
+		for level := 0; level <= len(current.skip); level++ {
+			last[level] = current
+		}
+
+		if i == length {
+			break
+		}
+
+		current = current.next
+	}
+
+	for i := 0; i < l.level; i++ {
+		last[i].setForward(i, nil)
+	}
+
+	l.len = length
+	l.shrink()
+}
+
+// Front node
+func (l *SkipList) Front() (*ListNode, error) {
+	if l.head.next == nil {
+		return nil, errors.New("Front: List is empty")
+	}
+	return l.head.next, nil
+}
+
+// Last node
+func (l *SkipList) Last() (*ListNode, error) {
+	if l.head.next == nil {
+		return nil, errors.New("Last: List is empty")
+	}
+
+	current := &l.head
+	for i := l.level - 1; i >= 0; i-- {
+		for current.forward(i) != nil {
+			current = current.forward(i)
+		}
+	}
+
+	return current, nil
+}
+
+// Size of list
+func (l *SkipList) Size() int {
+	return l.len
+}
+
+// Each calls fn for every node in list order until fn returns false
+func (l *SkipList) Each(fn func(node *ListNode) bool) {
+	for node := l.head.next; node != nil; node = node.next {
+		if !fn(node) {
+			return
+		}
+	}
+}
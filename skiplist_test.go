@@ -0,0 +1,259 @@
+package orderbook
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestSkipListUpdateOrAddAsc node
+func TestSkipListUpdateOrAddAsc(t *testing.T) {
+	list := NewSkipList(OrderBookSideAsks)
+
+	// Operations
+	list.UpdateOrAdd(124005, 100)
+	list.UpdateOrAdd(124000, 100)
+	list.UpdateOrAdd(123000, 200)
+	list.UpdateOrAdd(123500, 3300)
+	list.UpdateOrAdd(124000, 200)
+
+	if list.Size() != 4 {
+		t.Errorf("Invalid node count! Expected: %d, got: %d", 4, list.Size())
+		return
+	}
+
+	expected := []int64{123000, 123500, 124000, 124005}
+
+	node, _ := list.Front()
+	for i, price := range expected {
+		if node.Price != price {
+			t.Errorf("Invalid node %d! Expected: %d, got: %d", i, price, node.Price)
+		}
+		node = node.Next()
+	}
+
+	third := list.head.next.next.next
+	if third.Size != 200 {
+		t.Errorf("Invalid third node size! Expected: %d, got: %d", 200, third.Size)
+	}
+}
+
+// TestSkipListUpdateOrAddDesc node
+func TestSkipListUpdateOrAddDesc(t *testing.T) {
+	list := NewSkipList(OrderBookSideBids)
+
+	// Operations
+	list.UpdateOrAdd(124005, 100)
+	list.UpdateOrAdd(124000, 100)
+	list.UpdateOrAdd(123500, 3300)
+	list.UpdateOrAdd(123000, 200)
+	list.UpdateOrAdd(123500, 78012)
+
+	if list.Size() != 4 {
+		t.Errorf("Invalid node count! Expected: %d, got: %d", 4, list.Size())
+		return
+	}
+
+	expected := []int64{124005, 124000, 123500, 123000}
+
+	node, _ := list.Front()
+	for i, price := range expected {
+		if node.Price != price {
+			t.Errorf("Invalid node %d! Expected: %d, got: %d", i, price, node.Price)
+		}
+		node = node.Next()
+	}
+
+	last, _ := list.Last()
+	if last.Price != 123000 {
+		t.Errorf("Invalid last node! Expected: %d, got: %d", 123000, last.Price)
+	}
+}
+
+// TestSkipListRemove node
+func TestSkipListRemove(t *testing.T) {
+	list := NewSkipList(OrderBookSideAsks)
+	for price := int64(100); price < 1100; price++ {
+		list.UpdateOrAdd(price, price)
+	}
+
+	// Remove head node
+	err := list.Remove(100)
+	if err != nil {
+		t.Error(err)
+	}
+
+	front, _ := list.Front()
+	if front.Price != 101 || list.Size() != 999 {
+		t.Errorf("Invalid head node! Expected: %d, got: %d", 101, front.Price)
+	}
+
+	// Try to remove non-existing node
+	err = list.Remove(0)
+	if err == nil || list.Size() != 999 {
+		t.Errorf("Expected an error")
+	}
+
+	// Remove every other node
+	for price := int64(102); price < 1100; price += 2 {
+		if err := list.Remove(price); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if list.Size() != 500 {
+		t.Errorf("Invalid list length. Expected: %d, got: %d", 500, list.Size())
+	}
+
+	// Validate order
+	expected := int64(101)
+	for node, _ := list.Front(); node != nil; node = node.Next() {
+		if node.Price != expected {
+			t.Errorf("Invalid node! Expected: %d, got: %d", expected, node.Price)
+			return
+		}
+		expected += 2
+	}
+
+	// Remove front and back
+	list.RemoveFront()
+	list.RemoveBack()
+
+	front, _ = list.Front()
+	last, _ := list.Last()
+	if front.Price != 103 || last.Price != 1097 || list.Size() != 498 {
+		t.Errorf("Invalid list! Expected: %d..%d, got: %d..%d", 103, 1097, front.Price, last.Price)
+	}
+}
+
+// TestSkipListPrune nodes
+func TestSkipListPrune(t *testing.T) {
+	list := NewSkipList(OrderBookSideBids)
+	for price := int64(1); price <= 1000; price++ {
+		list.UpdateOrAdd(price, 1)
+	}
+
+	// Prune
+	list.Prune(300)
+
+	// Validate length
+	if list.Size() != 300 {
+		t.Errorf("Invalid list length! Expected: %d, got: %d", 300, list.Size())
+	}
+
+	// Validate last node
+	last, _ := list.Last()
+	if last.Price != 701 {
+		t.Errorf("Invalid last node! Expected: %d, got: %d", 701, last.Price)
+	}
+
+	// Pruned levels can be added again
+	list.UpdateOrAdd(500, 2)
+	list.UpdateOrAdd(1001, 2)
+
+	count := 0
+	for node, _ := list.Front(); node != nil; node = node.Next() {
+		count++
+	}
+
+	if count != 302 || list.Size() != 302 {
+		t.Errorf("Invalid list length! Expected: %d, got: %d (%d)", 302, count, list.Size())
+	}
+}
+
+// TestSetLevel keeps level order count on list sides
+func TestSetLevel(t *testing.T) {
+	for _, side := range []Side{NewList(OrderBookSideAsks), NewSkipList(OrderBookSideAsks)} {
+		setter := side.(LevelSetter)
+		setter.SetLevel(ListNode{Price: 2, Size: 5, Count: 3})
+		setter.SetLevel(ListNode{Price: 1, Size: 4, Count: 2})
+		setter.SetLevel(ListNode{Price: 2, Size: 6, Count: 4})
+
+		front, _ := side.Front()
+		if front.Price != 1 || front.Count != 2 {
+			t.Errorf("Invalid front count! Expected: %d, got: %d", 2, front.Count)
+		}
+
+		if next := front.Next(); next.Size != 6 || next.Count != 4 {
+			t.Errorf("Invalid level count! Expected: %d, got: %d", 4, next.Count)
+		}
+
+		// UpdateOrAdd resets count
+		side.UpdateOrAdd(1, 7)
+		if front, _ := side.Front(); front.Count != 0 {
+			t.Errorf("Invalid front count! Expected: %d, got: %d", 0, front.Count)
+		}
+	}
+}
+
+// benchmarkDepth book depth used by side benchmarks
+const benchmarkDepth = 5000
+
+// benchmarkUpdates random level updates around the top of a deep book
+func benchmarkUpdates(n int) []int64 {
+	r := rand.New(rand.NewSource(1))
+	prices := make([]int64, n)
+	for i := range prices {
+		prices[i] = 100000 + r.Int63n(benchmarkDepth)
+	}
+	return prices
+}
+
+// BenchmarkListUpdateOrAdd benchmarks List updates on deep book
+func BenchmarkListUpdateOrAdd(b *testing.B) {
+	list := &List{}
+	for price := int64(100000); price < 100000+benchmarkDepth; price++ {
+		list.UpdateOrAddAsc(price, 1)
+	}
+	prices := benchmarkUpdates(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.UpdateOrAddAsc(prices[i&1023], int64(i))
+	}
+}
+
+// BenchmarkSkipListUpdateOrAdd benchmarks SkipList updates on deep book
+func BenchmarkSkipListUpdateOrAdd(b *testing.B) {
+	list := NewSkipList(OrderBookSideAsks)
+	for price := int64(100000); price < 100000+benchmarkDepth; price++ {
+		list.UpdateOrAdd(price, 1)
+	}
+	prices := benchmarkUpdates(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		list.UpdateOrAdd(prices[i&1023], int64(i))
+	}
+}
+
+// BenchmarkListRemoveAdd benchmarks List level delete and insert on deep book
+func BenchmarkListRemoveAdd(b *testing.B) {
+	list := &List{}
+	for price := int64(100000); price < 100000+benchmarkDepth; price++ {
+		list.UpdateOrAddAsc(price, 1)
+	}
+	prices := benchmarkUpdates(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		price := prices[i&1023]
+		list.Remove(price)
+		list.UpdateOrAddAsc(price, 1)
+	}
+}
+
+// BenchmarkSkipListRemoveAdd benchmarks SkipList level delete and insert on deep book
+func BenchmarkSkipListRemoveAdd(b *testing.B) {
+	list := NewSkipList(OrderBookSideAsks)
+	for price := int64(100000); price < 100000+benchmarkDepth; price++ {
+		list.UpdateOrAdd(price, 1)
+	}
+	prices := benchmarkUpdates(1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		price := prices[i&1023]
+		list.Remove(price)
+		list.UpdateOrAdd(price, 1)
+	}
+}
@@ -0,0 +1,293 @@
+package orderbook
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BackPressure defines how subscription handles updates when its buffer is full
+type BackPressure int
+
+const (
+	// BackPressureDropOldest discards oldest buffered update, see Subscription.Dropped
+	BackPressureDropOldest BackPressure = iota
+	// BackPressureCoalesce merges all buffered updates into the new one, no level change is lost
+	BackPressureCoalesce
+	// BackPressureBlock waits for subscriber, stalls book updates until buffered update is received.
+	// ConcurrentOrderBook and BookManager wait after releasing book lock, subscriber may read book meanwhile.
+	BackPressureBlock
+)
+
+// LevelDelta level change applied to book, zero size removes level
+type LevelDelta struct {
+	Side  int
+	Price int64
+	Size  int64
+	// Count orders at level, 0 if unknown or removed
+	Count int64
+}
+
+// BookUpdate is sent to subscribers after each book change. Deltas hold levels set by event and levels
+// removed by CrossPolicyTrim, levels dropped by PruneThreshold are not reported. Snapshot is set when book
+// was replaced by snapshot or cleared, current levels must then be read from book or its View.
+type BookUpdate struct {
+	Symbol   string
+	UpdateID int64
+	Time     time.Time
+	Snapshot bool
+	Deltas   []LevelDelta
+	Before   BBO
+	After    BBO
+}
+
+// merge prepends older update
+func (u *BookUpdate) merge(older *BookUpdate) {
+	u.Before = older.Before
+	u.Snapshot = u.Snapshot || older.Snapshot
+
+	if u.Snapshot {
+		u.Deltas = nil
+		return
+	}
+
+	deltas := make([]LevelDelta, 0, len(older.Deltas)+len(u.Deltas))
+	deltas = append(deltas, older.Deltas...)
+	u.Deltas = append(deltas, u.Deltas...)
+}
+
+// Subscription receives book updates on C until closed
+type Subscription struct {
+	C <-chan *BookUpdate
+
+	c       chan *BookUpdate
+	policy  BackPressure
+	dropped uint64
+
+	mu     sync.Mutex
+	closed bool
+	done   chan struct{}
+	once   sync.Once
+}
+
+// pendingUpdate is update waiting for delivery to subscription
+type pendingUpdate struct {
+	s      *Subscription
+	update *BookUpdate
+}
+
+// Subscribe registers subscription buffering up to buffer updates, buffer below 1 is raised to 1
+func (ob *OrderBook) Subscribe(buffer int, policy BackPressure) *Subscription {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	c := make(chan *BookUpdate, buffer)
+	s := &Subscription{
+		C:      c,
+		c:      c,
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+
+	ob.subscriptions = append(ob.subscriptions, s)
+
+	return s
+}
+
+// SubscribeFunc registers subscription calling fn from its own goroutine for every update
+func (ob *OrderBook) SubscribeFunc(buffer int, policy BackPressure, fn func(update *BookUpdate)) *Subscription {
+	s := ob.Subscribe(buffer, policy)
+
+	go func() {
+		for update := range s.C {
+			fn(update)
+		}
+	}()
+
+	return s
+}
+
+// Close unsubscribes and closes C, safe to call more than once and from any goroutine
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.closed = true
+		close(s.c)
+	})
+}
+
+// isClosed reports whether Close was called, does not wait for pending send
+func (s *Subscription) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Dropped returns number of updates discarded by BackPressureDropOldest
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// send delivers update according to back-pressure policy, false once subscription is closed
+func (s *Subscription) send(update *BookUpdate) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	if s.policy == BackPressureBlock {
+		select {
+		case s.c <- update:
+		case <-s.done:
+			return false
+		}
+		return true
+	}
+
+	for {
+		select {
+		case s.c <- update:
+			return true
+		default:
+		}
+
+		// Buffer is full, make room. Subscriber may drain buffer meanwhile, send is then retried.
+		if s.policy == BackPressureCoalesce {
+			s.coalesce(update)
+			continue
+		}
+
+		select {
+		case <-s.c:
+			atomic.AddUint64(&s.dropped, 1)
+		default:
+		}
+	}
+}
+
+// coalesce merges all buffered updates into update
+func (s *Subscription) coalesce(update *BookUpdate) {
+	var buffered []*BookUpdate
+
+	for len(buffered) < cap(s.c) {
+		select {
+		case oldest := <-s.c:
+			buffered = append(buffered, oldest)
+			continue
+		default:
+		}
+		break
+	}
+
+	for i := len(buffered) - 1; i >= 0; i-- {
+		update.merge(buffered[i])
+	}
+}
+
+// subscribedBBO returns current best bid and offer if book has subscribers
+func (ob *OrderBook) subscribedBBO() BBO {
+	if len(ob.subscriptions) == 0 {
+		return BBO{}
+	}
+	return ob.BBO()
+}
+
+// recordEvent records event levels as deltas
+func (ob *OrderBook) recordEvent(event *DepthEvent) {
+	if len(ob.subscriptions) == 0 {
+		return
+	}
+
+	for _, ask := range event.Asks {
+		ob.record(OrderBookSideAsks, ask.level(event.FinalUpdateID, event.Timestamp), ask.Delete)
+	}
+
+	for _, bid := range event.Bids {
+		ob.record(OrderBookSideBids, bid.level(event.FinalUpdateID, event.Timestamp), bid.Delete)
+	}
+}
+
+// record level delta for subscribers
+func (ob *OrderBook) record(side int, level ListNode, delete bool) {
+	if len(ob.subscriptions) == 0 {
+		return
+	}
+
+	if delete || level.Size == 0 {
+		level.Size = 0
+		level.Count = 0
+	}
+
+	ob.deltas = append(ob.deltas, LevelDelta{Side: side, Price: level.Price, Size: level.Size, Count: level.Count})
+}
+
+// notify queues recorded deltas for subscribers, closed subscriptions are removed. Updates are delivered
+// right away unless book delivers them after unlock (ConcurrentOrderBook).
+func (ob *OrderBook) notify(before BBO, snapshot bool) {
+	deltas := ob.deltas
+	ob.deltas = nil
+
+	if len(ob.subscriptions) == 0 {
+		return
+	}
+
+	if snapshot {
+		deltas = nil
+	}
+
+	after := ob.BBO()
+	active := ob.subscriptions[:0]
+
+	for _, s := range ob.subscriptions {
+		if s.isClosed() {
+			continue
+		}
+
+		// Every subscriber gets own update, coalescing modifies it
+		update := &BookUpdate{
+			Symbol:   ob.Symbol,
+			UpdateID: ob.LastUpdateID,
+			Time:     ob.UpdatedAt,
+			Snapshot: snapshot,
+			Deltas:   append([]LevelDelta(nil), deltas...),
+			Before:   before,
+			After:    after,
+		}
+
+		ob.pending = append(ob.pending, pendingUpdate{s: s, update: update})
+		active = append(active, s)
+	}
+
+	for i := len(active); i < len(ob.subscriptions); i++ {
+		ob.subscriptions[i] = nil
+	}
+	ob.subscriptions = active
+
+	if !ob.deferDelivery {
+		deliver(ob.takePending())
+	}
+}
+
+// takePending returns queued updates
+func (ob *OrderBook) takePending() []pendingUpdate {
+	pending := ob.pending
+	ob.pending = nil
+	return pending
+}
+
+// deliver sends updates, updates of subscriptions closed meanwhile are discarded
+func deliver(pending []pendingUpdate) {
+	for _, p := range pending {
+		p.s.send(p.update)
+	}
+}
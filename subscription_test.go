@@ -0,0 +1,169 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSubscribe checks deltas and BBO before/after
+func TestSubscribe(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+	s := ob.Subscribe(4, BackPressureDropOldest)
+
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	update := <-s.C
+	if !update.Snapshot || update.After.BidPrice != 4799900000000 || update.After.AskPrice != 4800100000000 {
+		t.Errorf("Invalid snapshot update! Got: %+v", update)
+	}
+
+	ob.ProcessEvent(&DepthEvent{
+		FinalUpdateID: 101,
+		Asks:          []*Ask{{Price: 4800100000000, Delete: true}},
+		Bids:          []*Bid{{Price: 4799900000000, Quantity: 5}},
+	})
+
+	update = <-s.C
+	if update.Snapshot || len(update.Deltas) != 2 || update.UpdateID != 101 {
+		t.Errorf("Invalid update! Got: %+v", update)
+	}
+
+	if delta := update.Deltas[0]; delta.Side != OrderBookSideAsks || delta.Size != 0 {
+		t.Errorf("Invalid ask delta! Expected removal, got: %+v", delta)
+	}
+
+	if update.Before.AskPrice != 4800100000000 || update.After.AskPrice != 4800200000000 || update.After.BidSize != 5 {
+		t.Errorf("Invalid BBO! Before: %+v, after: %+v", update.Before, update.After)
+	}
+
+	// Rejected event is not sent
+	ob.ProcessEvent(&DepthEvent{FinalUpdateID: 101})
+	if len(s.C) != 0 {
+		t.Errorf("Invalid buffered updates! Expected: %d, got: %d", 0, len(s.C))
+	}
+
+	s.Close()
+	ob.ProcessEvent(&DepthEvent{FinalUpdateID: 102})
+
+	if _, ok := <-s.C; ok || len(ob.subscriptions) != 0 {
+		t.Errorf("Expected closed subscription")
+	}
+}
+
+// TestSubscribeBackPressure checks drop oldest and coalesce policies
+func TestSubscribeBackPressure(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	drop := ob.Subscribe(2, BackPressureDropOldest)
+	coalesce := ob.Subscribe(2, BackPressureCoalesce)
+
+	for i := int64(101); i <= 105; i++ {
+		ob.ProcessEvent(testEvent(i, i))
+	}
+
+	if drop.Dropped() != 3 {
+		t.Errorf("Invalid dropped count! Expected: %d, got: %d", 3, drop.Dropped())
+	}
+
+	if update := <-drop.C; update.UpdateID != 104 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 104, update.UpdateID)
+	}
+
+	// Single update holds all events in order
+	update := <-coalesce.C
+	if update.UpdateID != 105 || update.Before.UpdateID != 100 || len(update.Deltas) != 5 || len(coalesce.C) != 0 {
+		t.Errorf("Invalid coalesced update! Got: %+v", update)
+	}
+
+	for i, delta := range update.Deltas {
+		if expected := testEvent(0, 101+int64(i)).Asks[0].Price; delta.Price != expected {
+			t.Errorf("Invalid delta price! Expected: %d, got: %d", expected, delta.Price)
+		}
+	}
+}
+
+// TestSubscribeBlock checks blocked update is released by Close
+func TestSubscribeBlock(t *testing.T) {
+	ob := New("BTCUSDT", 10)
+	ob.ProcessSnapshot(testSnapshot(), nil)
+
+	s := ob.Subscribe(1, BackPressureBlock)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ob.ProcessEvent(testEvent(101, 101))
+		ob.ProcessEvent(testEvent(102, 102))
+	}()
+
+	select {
+	case <-done:
+		t.Errorf("Expected blocked update")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	s.Close()
+	<-done
+}
+
+// TestSubscribeBlockConcurrent checks blocking subscriber may read concurrent book
+func TestSubscribeBlockConcurrent(t *testing.T) {
+	c := NewConcurrent("BTCUSDT", 10)
+	c.ProcessSnapshot(testSnapshot(), nil)
+
+	updates := make(chan int64, 48)
+	c.SubscribeFunc(1, BackPressureBlock, func(update *BookUpdate) {
+		time.Sleep(time.Millisecond)
+		c.GetMarketPrice()
+		updates <- update.UpdateID
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(101); i <= 148; i++ {
+			c.ProcessEvent(testEvent(i, i))
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected updates to be delivered")
+	}
+
+	for i := int64(101); i <= 148; i++ {
+		if id := <-updates; id != i {
+			t.Errorf("Invalid update ID! Expected: %d, got: %d", i, id)
+		}
+	}
+}
+
+// TestBookManagerSubscribe checks callback subscription and close on Remove
+func TestBookManagerSubscribe(t *testing.T) {
+	m := NewBookManager(10, nil)
+
+	updates := make(chan int64, 4)
+	s := m.SubscribeFunc("BTCUSDT", 4, BackPressureBlock, func(update *BookUpdate) {
+		updates <- update.UpdateID
+	})
+
+	snapshot := testSnapshot()
+	snapshot.Symbol = "BTCUSDT"
+	m.ProcessSnapshot(snapshot, nil)
+	m.ProcessEvent(testEvent(101, 101))
+
+	if id := <-updates; id != 100 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 100, id)
+	}
+
+	if id := <-updates; id != 101 {
+		t.Errorf("Invalid update ID! Expected: %d, got: %d", 101, id)
+	}
+
+	m.Remove("BTCUSDT")
+	if _, ok := <-s.C; ok {
+		t.Errorf("Expected closed subscription")
+	}
+}
@@ -0,0 +1,220 @@
+package orderbook
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// SyncState synchronizer state
+type SyncState int
+
+const (
+	// SyncStateSyncing waiting for initial snapshot
+	SyncStateSyncing SyncState = iota
+	// SyncStateLive book is loaded and updated from events
+	SyncStateLive
+	// SyncStateResyncing book lost continuity, waiting for new snapshot
+	SyncStateResyncing
+)
+
+// String returns state name
+func (s SyncState) String() string {
+	switch s {
+	case SyncStateSyncing:
+		return "syncing"
+	case SyncStateLive:
+		return "live"
+	case SyncStateResyncing:
+		return "resyncing"
+	}
+	return "unknown"
+}
+
+const (
+	// DefaultSyncMaxBuffer events buffered while waiting for snapshot
+	DefaultSyncMaxBuffer = 1000
+	// DefaultSyncMaxRetries immediate snapshot re-fetches when snapshot is too old for buffered events
+	DefaultSyncMaxRetries = 3
+	// DefaultSyncFetchInterval min time between snapshot requests after failed sync
+	DefaultSyncFetchInterval = time.Second
+)
+
+// ErrSnapshotTooOld is returned when fetched snapshot does not reach first buffered event
+var ErrSnapshotTooOld = errors.New("snapshot is too old for buffered events")
+
+// SnapshotFetcher fetches depth snapshot, e.g. from exchange REST API
+type SnapshotFetcher interface {
+	FetchSnapshot(symbol string) (*DepthSnapshot, error)
+}
+
+// SnapshotFetcherFunc adapts function to SnapshotFetcher
+type SnapshotFetcherFunc func(symbol string) (*DepthSnapshot, error)
+
+// FetchSnapshot calls f
+func (f SnapshotFetcherFunc) FetchSnapshot(symbol string) (*DepthSnapshot, error) {
+	return f(symbol)
+}
+
+// Synchronizer keeps OrderBook in sync with depth event stream: events are buffered while book
+// is not loaded, snapshot is fetched and applied with buffered events, then events are streamed.
+// Synchronizer is not safe for concurrent use, except State.
+type Synchronizer struct {
+	Book *OrderBook
+
+	// MaxBuffer events kept while waiting for snapshot, oldest are dropped
+	MaxBuffer int
+	// MaxRetries immediate re-fetches when snapshot is too old for buffered events
+	MaxRetries int
+	// FetchInterval min time between snapshot requests after failed sync
+	FetchInterval time.Duration
+	// OnStateChange is called on every state transition
+	OnStateChange func(from, to SyncState)
+
+	fetcher   SnapshotFetcher
+	state     int32
+	buffer    []*DepthEvent
+	lastFetch time.Time
+
+	// lock runs fn with exclusive access to Book, set when Book is shared (e.g. by BookManager)
+	lock func(fn func(ob *OrderBook))
+}
+
+// NewSynchronizer creates new struct instance of *Synchronizer
+func NewSynchronizer(book *OrderBook, fetcher SnapshotFetcher) *Synchronizer {
+	return &Synchronizer{
+		Book:          book,
+		MaxBuffer:     DefaultSyncMaxBuffer,
+		MaxRetries:    DefaultSyncMaxRetries,
+		FetchInterval: DefaultSyncFetchInterval,
+		fetcher:       fetcher,
+	}
+}
+
+// State returns current state, safe to call concurrently with HandleEvent
+func (s *Synchronizer) State() SyncState {
+	return SyncState(atomic.LoadInt32(&s.state))
+}
+
+// setState changes state and notifies callback
+func (s *Synchronizer) setState(state SyncState) {
+	from := s.State()
+	if from == state {
+		return
+	}
+
+	atomic.StoreInt32(&s.state, int32(state))
+
+	if s.OnStateChange != nil {
+		s.OnStateChange(from, state)
+	}
+}
+
+// withBook calls fn with Book, under lock when set. Snapshots are fetched outside of it.
+func (s *Synchronizer) withBook(fn func(ob *OrderBook)) {
+	if s.lock != nil {
+		s.lock(fn)
+		return
+	}
+	fn(s.Book)
+}
+
+// Resync marks book not loaded, snapshot is fetched on next event
+func (s *Synchronizer) Resync() {
+	s.withBook(func(ob *OrderBook) {
+		ob.Loaded = false
+	})
+	s.buffer = s.buffer[:0]
+	s.lastFetch = time.Time{}
+	s.setState(SyncStateResyncing)
+}
+
+// ProcessSnapshot applies snapshot supplied by caller with eventBuffer, events buffered by synchronizer are
+// discarded. Book goes live once loaded, errors that leave it not loaded keep state.
+func (s *Synchronizer) ProcessSnapshot(snapshot *DepthSnapshot, eventBuffer []*DepthEvent) error {
+	var err error
+	var loaded bool
+	s.withBook(func(ob *OrderBook) {
+		err = ob.ProcessSnapshot(snapshot, eventBuffer)
+		loaded = ob.Loaded
+	})
+
+	if !loaded {
+		return err
+	}
+
+	s.buffer = s.buffer[:0]
+	s.lastFetch = time.Time{}
+	s.setState(SyncStateLive)
+
+	return err
+}
+
+// HandleEvent applies event to live book, otherwise buffers event and tries to sync.
+// Errors that leave book loaded (e.g. stale event) are returned as is, errors that unload it start resync.
+func (s *Synchronizer) HandleEvent(event *DepthEvent) error {
+	if s.State() == SyncStateLive {
+		var err error
+		var loaded bool
+		s.withBook(func(ob *OrderBook) {
+			err = ob.ProcessEvent(event)
+			loaded = ob.Loaded
+		})
+
+		if err == nil || loaded {
+			return err
+		}
+
+		// Continuity lost, event starts new buffer
+		s.Resync()
+	}
+
+	// Buffer event
+	if s.MaxBuffer > 0 && len(s.buffer) >= s.MaxBuffer {
+		copy(s.buffer, s.buffer[1:])
+		s.buffer = s.buffer[:len(s.buffer)-1]
+	}
+	s.buffer = append(s.buffer, event)
+
+	// Throttle snapshot requests
+	if !s.lastFetch.IsZero() && time.Since(s.lastFetch) < s.FetchInterval {
+		return nil
+	}
+
+	return s.sync()
+}
+
+// sync fetches snapshot and applies it with buffered events
+func (s *Synchronizer) sync() error {
+	for attempt := 0; attempt <= s.MaxRetries; attempt++ {
+		s.lastFetch = time.Now()
+
+		snapshot, err := s.fetcher.FetchSnapshot(s.Book.Symbol)
+		if err != nil {
+			return err
+		}
+
+		// Snapshot must reach first buffered event
+		first := s.buffer[0]
+		if first.FirstUpdateID > snapshot.LastUpdateID+1 {
+			continue
+		}
+
+		s.withBook(func(ob *OrderBook) {
+			err = ob.ProcessSnapshot(snapshot, s.buffer)
+		})
+		s.buffer = s.buffer[:0]
+
+		if err != nil {
+			// Gap within buffered events
+			return err
+		}
+
+		s.lastFetch = time.Time{}
+		s.setState(SyncStateLive)
+
+		return nil
+	}
+
+	return ErrSnapshotTooOld
+}
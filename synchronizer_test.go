@@ -0,0 +1,114 @@
+package orderbook
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeFetcher returns queued snapshots
+type fakeFetcher struct {
+	snapshots []*DepthSnapshot
+	calls     int
+}
+
+func (f *fakeFetcher) FetchSnapshot(symbol string) (*DepthSnapshot, error) {
+	f.calls++
+	if len(f.snapshots) == 0 {
+		return nil, errors.New("no snapshot")
+	}
+
+	snapshot := f.snapshots[0]
+	f.snapshots = f.snapshots[1:]
+	return snapshot, nil
+}
+
+// testEvent depth event updating single ask
+func testEvent(first, final int64) *DepthEvent {
+	return &DepthEvent{
+		Symbol:        "BTCUSDT",
+		FirstUpdateID: first,
+		FinalUpdateID: final,
+		Asks:          []*Ask{{Price: 4800100000000 + final, Quantity: 1000000}},
+	}
+}
+
+// TestSynchronizer checks sync, too old snapshot re-fetch and resync on gap
+func TestSynchronizer(t *testing.T) {
+	old := testSnapshot()
+	old.LastUpdateID = 90
+
+	fetcher := &fakeFetcher{
+		snapshots: []*DepthSnapshot{old, testSnapshot()},
+	}
+
+	states := []SyncState{}
+
+	sync := NewSynchronizer(New("BTCUSDT", 100), fetcher)
+	sync.OnStateChange = func(from, to SyncState) {
+		states = append(states, to)
+	}
+
+	// First event triggers fetch, first snapshot is too old
+	if err := sync.HandleEvent(testEvent(95, 100)); err != nil {
+		t.Error(err)
+	}
+
+	if fetcher.calls != 2 || sync.State() != SyncStateLive || sync.Book.LastUpdateID != 100 {
+		t.Errorf("Expected live book after %d fetches, got: %d fetches, state %s", 2, fetcher.calls, sync.State())
+	}
+
+	// Streaming
+	if err := sync.HandleEvent(testEvent(101, 102)); err != nil {
+		t.Error(err)
+	}
+
+	if sync.Book.LastUpdateID != 102 || sync.Book.Asks.Size() != 6 {
+		t.Errorf("Invalid book! Expected update ID %d, got: %d", 102, sync.Book.LastUpdateID)
+	}
+
+	// Stale event keeps book live
+	if err := sync.HandleEvent(testEvent(101, 102)); err == nil || sync.State() != SyncStateLive {
+		t.Errorf("Expected stale event error")
+	}
+
+	// Gap starts resync, fetch fails and event stays buffered
+	if err := sync.HandleEvent(testEvent(110, 111)); err == nil {
+		t.Errorf("Expected fetch error")
+	}
+
+	if sync.State() != SyncStateResyncing || sync.Book.Loaded {
+		t.Errorf("Expected resyncing state, got: %s", sync.State())
+	}
+
+	// Next fetch is throttled
+	sync.HandleEvent(testEvent(112, 113))
+	if fetcher.calls != 3 || len(sync.buffer) != 2 {
+		t.Errorf("Expected throttled fetch, got: %d fetches, %d buffered events", fetcher.calls, len(sync.buffer))
+	}
+
+	// Snapshot arrives
+	resync := testSnapshot()
+	resync.LastUpdateID = 111
+	fetcher.snapshots = append(fetcher.snapshots, resync)
+	sync.FetchInterval = 0
+
+	if err := sync.HandleEvent(testEvent(114, 115)); err != nil {
+		t.Error(err)
+	}
+
+	if sync.State() != SyncStateLive || sync.Book.LastUpdateID != 115 {
+		t.Errorf("Expected live book at update ID %d, got: %d, state %s", 115, sync.Book.LastUpdateID, sync.State())
+	}
+
+	expected := []SyncState{SyncStateLive, SyncStateResyncing, SyncStateLive}
+	if len(states) != len(expected) {
+		t.Errorf("Invalid state changes! Expected: %v, got: %v", expected, states)
+		return
+	}
+
+	for i := range expected {
+		if states[i] != expected[i] {
+			t.Errorf("Invalid state changes! Expected: %v, got: %v", expected, states)
+		}
+	}
+}
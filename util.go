@@ -53,23 +53,7 @@ func DecimalStringToSatoshi(value string) int64 {
 
 // SatoshiToDecimalString converts int64 (satoshi) value to decimal string
 func SatoshiToDecimalString(value int64) string {
-	valueStr := strconv.FormatInt(value, 10)
-	length := len(valueStr)
-
-	if length < 8 {
-		// Prepend zeros
-		for i := (8 - length); i > 0; i-- {
-			valueStr = "0" + valueStr
-		}
-
-		valueStr = ("0." + valueStr)
-	} else if length == 8 {
-		valueStr = ("0." + valueStr)
-	} else {
-		valueStr = valueStr[:length-8] + "." + valueStr[length-8:]
-	}
-
-	return valueStr
+	return intToDecimalString(value, -PriceDecimalExp)
 }
 
 // DecimalStringToSize parses decimal string to int64 (Size)
@@ -85,23 +69,7 @@ func DecimalStringToSize(value string) int64 {
 
 // SizeToDecimalString converts int64 (size) value to decimal string
 func SizeToDecimalString(value int64) string {
-	valueStr := strconv.FormatInt(value, 10)
-	length := len(valueStr)
-
-	if length < 6 {
-		// Prepend zeros
-		for i := (6 - length); i > 0; i-- {
-			valueStr = "0" + valueStr
-		}
-
-		valueStr = ("0." + valueStr)
-	} else if length == 6 {
-		valueStr = ("0." + valueStr)
-	} else {
-		valueStr = valueStr[:length-6] + "." + valueStr[length-6:]
-	}
-
-	return valueStr
+	return intToDecimalString(value, -SizeDecimalExp)
 }
 
 // DecimalStringToPercentageInt parses decimal string to int32 (Size)
@@ -117,23 +85,7 @@ func DecimalStringToPercentageInt(value string) int32 {
 
 // PercentageIntToDecimalString converts int32 (percentage) value to decimal string
 func PercentageIntToDecimalString(value int32) string {
-	valueStr := strconv.FormatInt(int64(value), 10)
-	length := len(valueStr)
-
-	if length < 4 {
-		// Prepend zeros
-		for i := (4 - length); i > 0; i-- {
-			valueStr = "0" + valueStr
-		}
-
-		valueStr = ("0." + valueStr)
-	} else if length == 4 {
-		valueStr = ("0." + valueStr)
-	} else {
-		valueStr = valueStr[:length-4] + "." + valueStr[length-4:]
-	}
-
-	return valueStr
+	return intToDecimalString(int64(value), -PercentageDecimalExp)
 }
 
 // DecimalToStatoshi converts (shifts) decimal.Decimal to int64 (satoshi)
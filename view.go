@@ -0,0 +1,410 @@
+package orderbook
+
+import (
+	"errors"
+	"time"
+)
+
+// BookView is immutable book version published after each update, safe for use from any goroutine
+type BookView struct {
+	bookSides
+
+	Symbol       string
+	LastUpdateID int64
+	UpdatedAt    time.Time
+	Loaded       bool
+}
+
+// WithViews publishes immutable BookView after each update, see OrderBook.View.
+// Sides are wrapped to mirror changes into persistent trees, so Asks and Bids are no longer
+// the concrete types created by side factory.
+func WithViews() Option {
+	return func(ob *OrderBook) {
+		ob.views = true
+	}
+}
+
+// View returns latest published book version, nil if views are disabled.
+// View never blocks and can be called concurrently with updates.
+func (ob *OrderBook) View() *BookView {
+	view, _ := ob.view.Load().(*BookView)
+	return view
+}
+
+// publish stores current book version
+func (ob *OrderBook) publish() {
+	asks, ok := ob.Asks.(*viewSide)
+	if !ok {
+		return
+	}
+
+	bids, ok := ob.Bids.(*viewSide)
+	if !ok {
+		return
+	}
+
+	ob.view.Store(&BookView{
+		bookSides: bookSides{
+			Asks:       asks.snapshot(),
+			Bids:       bids.snapshot(),
+			Instrument: ob.Instrument,
+		},
+		Symbol:       ob.Symbol,
+		LastUpdateID: ob.LastUpdateID,
+		UpdatedAt:    ob.UpdatedAt,
+		Loaded:       ob.Loaded,
+	})
+}
+
+// viewSide mirrors side changes into persistent tree
+type viewSide struct {
+	Side
+
+	tree viewTree
+}
+
+// newViewSide wraps side
+func newViewSide(side Side, sideType int) *viewSide {
+	v := &viewSide{
+		Side: side,
+		tree: viewTree{desc: sideType == OrderBookSideBids},
+	}
+	v.rebuild()
+
+	return v
+}
+
+// UpdateOrAdd level
+func (v *viewSide) UpdateOrAdd(price, size int64) {
+	v.Side.UpdateOrAdd(price, size)
+	v.tree.set(ListNode{Price: price, Size: size})
+}
+
+// SetLevel sets level with metadata, metadata is dropped when wrapped side does not keep it
+func (v *viewSide) SetLevel(level ListNode) {
+	if setter, ok := v.Side.(LevelSetter); ok {
+		setter.SetLevel(level)
+	} else {
+		v.Side.UpdateOrAdd(level.Price, level.Size)
+		level = ListNode{Price: level.Price, Size: level.Size}
+	}
+	v.tree.set(level)
+}
+
+// Dropped returns wrapped side dropped levels, 0 if side does not report them
+func (v *viewSide) Dropped() int64 {
+	if reporter, ok := v.Side.(DropReporter); ok {
+		return reporter.Dropped()
+	}
+	return 0
+}
+
+// Remove level, tree level is removed even if side did not hold it
+func (v *viewSide) Remove(price int64) error {
+	v.tree.remove(price)
+	return v.Side.Remove(price)
+}
+
+// Prune levels
+func (v *viewSide) Prune(length int) {
+	v.Side.Prune(length)
+	if length > 0 {
+		v.tree.root = v.tree.take(v.tree.root, length)
+	}
+}
+
+// rebuild copies side levels into new tree
+func (v *viewSide) rebuild() {
+	v.tree.root = nil
+	v.Side.Each(func(node *ListNode) bool {
+		v.tree.set(*node)
+		return true
+	})
+}
+
+// snapshot returns immutable side version. Tree holds a superset of side levels when side drops
+// levels on its own (e.g. Ladder window), in that case level counts differ and tree is rebuilt.
+func (v *viewSide) snapshot() *SideView {
+	if v.tree.root.len() != v.Side.Size() {
+		v.rebuild()
+	}
+
+	return &SideView{
+		root: v.tree.root,
+		desc: v.tree.desc,
+	}
+}
+
+// SideView is immutable side version
+type SideView struct {
+	root *viewNode
+	desc bool
+}
+
+// Front returns best level
+func (s *SideView) Front() (*ListNode, error) {
+	if s.root == nil {
+		return nil, errors.New("Front: List is empty")
+	}
+
+	n := s.root
+	for n.left != nil {
+		n = n.left
+	}
+
+	node := &ListNode{}
+	n.fill(node)
+
+	return node, nil
+}
+
+// Each calls fn for every level in priority order until fn returns false, node is reused between calls
+func (s *SideView) Each(fn func(node *ListNode) bool) {
+	var node ListNode
+	var stack []*viewNode
+
+	n := s.root
+	for n != nil || len(stack) > 0 {
+		for n != nil {
+			stack = append(stack, n)
+			n = n.left
+		}
+
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		n.fill(&node)
+		if !fn(&node) {
+			return
+		}
+
+		n = n.right
+	}
+}
+
+// Size returns level count
+func (s *SideView) Size() int {
+	return s.root.len()
+}
+
+// viewNode is persistent treap node, nodes are never modified once published
+type viewNode struct {
+	price    int64
+	size     int64
+	orders   int64
+	updateID int64
+	updated  time.Time
+	priority uint64
+	count    int
+
+	left  *viewNode
+	right *viewNode
+}
+
+// len returns subtree node count
+func (n *viewNode) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+// fill copies node level into list node
+func (n *viewNode) fill(node *ListNode) {
+	node.Price = n.price
+	node.Size = n.size
+	node.Count = n.orders
+	node.UpdateID = n.updateID
+	node.UpdatedAt = n.updated
+}
+
+// clone copies node for path copying
+func (n *viewNode) clone() *viewNode {
+	c := *n
+	return &c
+}
+
+// recount updates node count from children
+func (n *viewNode) recount() *viewNode {
+	n.count = 1 + n.left.len() + n.right.len()
+	return n
+}
+
+// viewTree is persistent treap ordered by side priority, priorities are derived from price
+type viewTree struct {
+	root *viewNode
+	desc bool
+}
+
+// before reports whether price a is ordered before price b
+func (t *viewTree) before(a, b int64) bool {
+	if t.desc {
+		return a > b
+	}
+	return a < b
+}
+
+// priority hashes price (splitmix64)
+func priority(price int64) uint64 {
+	x := uint64(price) + 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// find returns node with price
+func (t *viewTree) find(price int64) *viewNode {
+	n := t.root
+	for n != nil && n.price != price {
+		if t.before(price, n.price) {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return n
+}
+
+// set level
+func (t *viewTree) set(level ListNode) {
+	if n := t.find(level.Price); n != nil {
+		if n.size != level.Size || n.orders != level.Count || n.updateID != level.UpdateID || !n.updated.Equal(level.UpdatedAt) {
+			t.root = t.update(t.root, level)
+		}
+		return
+	}
+
+	t.root = t.insert(t.root, &viewNode{
+		price:    level.Price,
+		size:     level.Size,
+		orders:   level.Count,
+		updateID: level.UpdateID,
+		updated:  level.UpdatedAt,
+		priority: priority(level.Price),
+		count:    1,
+	})
+}
+
+// remove level
+func (t *viewTree) remove(price int64) {
+	if t.find(price) == nil {
+		return
+	}
+
+	t.root = t.delete(t.root, price)
+}
+
+// update copies path to existing node with new level
+func (t *viewTree) update(n *viewNode, level ListNode) *viewNode {
+	c := n.clone()
+
+	switch {
+	case n.price == level.Price:
+		c.size = level.Size
+		c.orders = level.Count
+		c.updateID = level.UpdateID
+		c.updated = level.UpdatedAt
+	case t.before(level.Price, n.price):
+		c.left = t.update(n.left, level)
+	default:
+		c.right = t.update(n.right, level)
+	}
+
+	return c
+}
+
+// insert node not present in subtree
+func (t *viewTree) insert(n, node *viewNode) *viewNode {
+	if n == nil {
+		return node
+	}
+
+	if node.priority > n.priority {
+		node.left, node.right = t.split(n, node.price)
+		return node.recount()
+	}
+
+	c := n.clone()
+	if t.before(node.price, n.price) {
+		c.left = t.insert(n.left, node)
+	} else {
+		c.right = t.insert(n.right, node)
+	}
+
+	return c.recount()
+}
+
+// delete node present in subtree
+func (t *viewTree) delete(n *viewNode, price int64) *viewNode {
+	if n.price == price {
+		return t.merge(n.left, n.right)
+	}
+
+	c := n.clone()
+	if t.before(price, n.price) {
+		c.left = t.delete(n.left, price)
+	} else {
+		c.right = t.delete(n.right, price)
+	}
+
+	return c.recount()
+}
+
+// split subtree into nodes ordered before price and the rest
+func (t *viewTree) split(n *viewNode, price int64) (*viewNode, *viewNode) {
+	if n == nil {
+		return nil, nil
+	}
+
+	c := n.clone()
+	if t.before(n.price, price) {
+		var right *viewNode
+		c.right, right = t.split(n.right, price)
+		return c.recount(), right
+	}
+
+	var left *viewNode
+	left, c.left = t.split(n.left, price)
+	return left, c.recount()
+}
+
+// merge subtrees, all nodes of a are ordered before nodes of b
+func (t *viewTree) merge(a, b *viewNode) *viewNode {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if a.priority > b.priority {
+		c := a.clone()
+		c.right = t.merge(a.right, b)
+		return c.recount()
+	}
+
+	c := b.clone()
+	c.left = t.merge(a, b.left)
+	return c.recount()
+}
+
+// take returns subtree of first length nodes
+func (t *viewTree) take(n *viewNode, length int) *viewNode {
+	if n == nil || length <= 0 {
+		return nil
+	}
+
+	if length >= n.count {
+		return n
+	}
+
+	left := n.left.len()
+	if length <= left {
+		return t.take(n.left, length)
+	}
+
+	c := n.clone()
+	c.right = t.take(n.right, length-left-1)
+	return c.recount()
+}
@@ -0,0 +1,162 @@
+package orderbook
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// sideLevels returns side levels in priority order
+func sideLevels(side SideReader) []ListNode {
+	levels := []ListNode{}
+	side.Each(func(node *ListNode) bool {
+		levels = append(levels, ListNode{Price: node.Price, Size: node.Size})
+		return true
+	})
+	return levels
+}
+
+// equalLevels compares side levels
+func equalLevels(t *testing.T, name string, expected, got SideReader) {
+	a, b := sideLevels(expected), sideLevels(got)
+	if len(a) != len(b) || expected.Size() != got.Size() {
+		t.Errorf("%s: Invalid level count! Expected: %d, got: %d", name, len(a), len(b))
+		return
+	}
+
+	for i := range a {
+		if a[i].Price != b[i].Price || a[i].Size != b[i].Size {
+			t.Errorf("%s: Invalid level %d! Expected: %d/%d, got: %d/%d", name, i, a[i].Price, a[i].Size, b[i].Price, b[i].Size)
+			return
+		}
+	}
+}
+
+// TestViews checks published views follow book and stay immutable
+func TestViews(t *testing.T) {
+	options := map[string][]Option{
+		"skiplist": {WithViews()},
+		"ladder":   {WithViews(), WithLadder(100000000, 64)},
+	}
+
+	for name, opts := range options {
+		ob := New("BTCUSDT", 30, opts...)
+		if view := ob.View(); view == nil || view.Asks.Size() != 0 {
+			t.Errorf("%s: Expected empty view", name)
+			continue
+		}
+
+		ob.ProcessSnapshot(testSnapshot(), nil)
+		first := ob.View()
+		firstLevels := sideLevels(first.Asks)
+
+		r := rand.New(rand.NewSource(1))
+		for id := int64(101); id < 1000; id++ {
+			event := &DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: id, FinalUpdateID: id}
+
+			price := 4800000000000 + r.Int63n(100)*100000000
+			if r.Intn(3) == 0 {
+				event.Asks = append(event.Asks, &Ask{Price: price, Delete: true})
+			} else {
+				event.Asks = append(event.Asks, &Ask{Price: price, Quantity: r.Int63n(1000) + 1})
+			}
+
+			price = 4799900000000 - r.Int63n(100)*100000000
+			event.Bids = append(event.Bids, &Bid{Price: price, Quantity: r.Int63n(1000) + 1})
+
+			if err := ob.ProcessEvent(event); err != nil {
+				t.Errorf("%s: %s", name, err)
+				break
+			}
+
+			view := ob.View()
+			if view.LastUpdateID != id {
+				t.Errorf("%s: Invalid view update ID! Expected: %d, got: %d", name, id, view.LastUpdateID)
+			}
+
+			equalLevels(t, name+" asks", ob.Asks, view.Asks)
+			equalLevels(t, name+" bids", ob.Bids, view.Bids)
+		}
+
+		// First view is unchanged
+		levels := sideLevels(first.Asks)
+		if len(levels) != len(firstLevels) || first.LastUpdateID != 100 {
+			t.Errorf("%s: Published view changed", name)
+		}
+
+		for i := range levels {
+			if levels[i].Price != firstLevels[i].Price || levels[i].Size != firstLevels[i].Size {
+				t.Errorf("%s: Published view changed", name)
+			}
+		}
+
+		// Conversions on view
+		expected, _ := ob.GetMarketPrice()
+		price, _ := ob.View().GetMarketPrice()
+		if !price.Equal(expected) {
+			t.Errorf("%s: Invalid view market price! Expected: %s, got: %s", name, expected, price)
+		}
+
+		ob.Clear()
+		if view := ob.View(); view.Loaded || view.Asks.Size() != 0 {
+			t.Errorf("%s: Expected empty view after Clear", name)
+		}
+	}
+}
+
+// TestViewsConcurrent reads views while writer updates book, run with -race
+func TestViewsConcurrent(t *testing.T) {
+	book := NewConcurrent("BTCUSDT", 10, WithViews())
+	book.ProcessSnapshot(testSnapshot(), nil)
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		for id := int64(101); id < 600; id++ {
+			book.ProcessEvent(&DepthEvent{
+				Symbol:        "BTCUSDT",
+				FirstUpdateID: id,
+				FinalUpdateID: id,
+				Asks:          []*Ask{{Price: 4800100000000, Quantity: id}},
+			})
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				view := book.View()
+				ask, err := view.Asks.Front()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				// First ask size follows update ID
+				if view.LastUpdateID > 100 && ask.Size != view.LastUpdateID {
+					t.Errorf("Inconsistent view! Expected size: %d, got: %d", view.LastUpdateID, ask.Size)
+				}
+
+				view.OrderBookAskConversion(decimal.NewFromInt(100000))
+			}
+		}()
+	}
+
+	wg.Wait()
+}